@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 
@@ -84,6 +86,7 @@ import (
 
 	gaiaappparams "github.com/Agoric/cosmic-swingset/app/params"
 	"github.com/Agoric/cosmic-swingset/x/swingset"
+	swingsetrest "github.com/Agoric/cosmic-swingset/x/swingset/client/rest"
 
 	// This is for the swagger file for legacy support
 	_ "github.com/cosmos/cosmos-sdk/client/docs/statik"
@@ -91,6 +94,12 @@ import (
 
 const appName = "agoric"
 
+// upgradeSwingSetStorage is the software upgrade that brings SwingSet storage
+// from before its module parameters to the current layout: it sets the
+// parameters, moves storage to escaped paths and byte values, and rebuilds
+// the keys index and usage.
+const upgradeSwingSetStorage = "swingset-storage-v2"
+
 var (
 	// DefaultNodeHome default home directories for the application daemon
 	DefaultNodeHome string
@@ -151,8 +160,6 @@ type GaiaApp struct {
 
 	invCheckPeriod uint
 
-	controllerInited bool
-
 	// keys to access the substores
 	keys    map[string]*sdk.KVStoreKey
 	tKeys   map[string]*sdk.TransientStoreKey
@@ -181,6 +188,16 @@ type GaiaApp struct {
 	SwingSetKeeper       swingset.Keeper
 	ScopedSwingSetKeeper capabilitykeeper.ScopedKeeper
 
+	// SwingSetController holds this app's controller ports and context.
+	SwingSetController *swingset.Controller
+	// SwingSetStorageWatcher publishes committed SwingSet storage changes.
+	SwingSetStorageWatcher *swingset.StorageWatcher
+	// SwingSetWatchOrigins are the browser origins besides the node's own
+	// that may watch storage over websockets.
+	SwingSetWatchOrigins []string
+	// SwingSetStorageFiles locates the SwingSet storage files beside genesis.
+	SwingSetStorageFiles *swingset.StorageFilesConfig
+
 	// the module manager
 	mm *module.Manager
 
@@ -195,6 +212,8 @@ func init() {
 	}
 
 	DefaultNodeHome = filepath.Join(userHomeDir, ".ag-chain-cosmos")
+
+	swingset.RegisterUpcallSchema("AG_COSMOS_INIT", "", cosmosInitAction{})
 }
 
 // NewGaia returns a reference to an initialized Gaia.
@@ -203,9 +222,9 @@ func NewGaiaApp(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bool, skipUpgradeHeights map[int64]bool,
 	homePath string, invCheckPeriod uint, encodingConfig gaiaappparams.EncodingConfig, baseAppOptions ...func(*baseapp.BaseApp),
 ) *GaiaApp {
-	defaultController := func(needReply bool, str string) (string, error) {
+	defaultController := func(ctx context.Context, needReply bool, str string) (string, error) {
 		fmt.Fprintln(os.Stderr, "FIXME: Would upcall to controller with", str)
-		return "", nil
+		return "true", nil
 	}
 	return NewAgoricApp(
 		defaultController,
@@ -215,7 +234,7 @@ func NewGaiaApp(
 }
 
 func NewAgoricApp(
-	sendToController func(bool, string) (string, error),
+	sendToController func(context.Context, bool, string) (string, error),
 	logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bool, skipUpgradeHeights map[int64]bool,
 	homePath string, invCheckPeriod uint, encodingConfig gaiaappparams.EncodingConfig, baseAppOptions ...func(*baseapp.BaseApp),
 ) *GaiaApp {
@@ -248,6 +267,12 @@ func NewAgoricApp(
 		tKeys:             tkeys,
 		memKeys:           memKeys,
 	}
+	app.SwingSetController = swingset.NewController()
+	app.SwingSetStorageWatcher = swingset.NewStorageWatcher()
+	app.SwingSetStorageFiles = &swingset.StorageFilesConfig{
+		Dir:            filepath.Join(homePath, "config"),
+		EntriesPerFile: swingset.DefaultStorageEntriesPerFile,
+	}
 
 	app.ParamsKeeper = initParamsKeeper(appCodec, cdc, keys[paramstypes.StoreKey], tkeys[paramstypes.TStoreKey])
 
@@ -319,20 +344,24 @@ func NewAgoricApp(
 	// The SwingSetKeeper is the Keeper from the SwingSet module
 	// It handles interactions with the kvstore and IBC.
 	app.SwingSetKeeper = swingset.NewKeeper(
-		appCodec, keys[swingset.StoreKey],
+		appCodec, keys[swingset.StoreKey], app.GetSubspace(swingset.ModuleName),
 		app.IBCKeeper.ChannelKeeper, &app.IBCKeeper.PortKeeper,
 		app.AccountKeeper, app.BankKeeper,
 		scopedSwingSetKeeper,
 	)
 	// This function is tricky to get right, so we inject it ourselves.
-	app.SwingSetKeeper.CallToController = func(ctx sdk.Context, str string) (string, error) {
-		defer swingset.SetControllerContext(ctx)()
-		defer swingset.SetControllerKeeper(&app.SwingSetKeeper)()
-		return sendToController(true, str)
+	app.SwingSetKeeper.CallToController = func(ctx sdk.Context, actionType, str string) (string, error) {
+		return app.SwingSetController.Call(ctx, &app.SwingSetKeeper, actionType, str, sendToController)
 	}
 
-	swingsetModule := swingset.NewAppModule(app.SwingSetKeeper)
-	app.IBCPort = swingset.RegisterPortHandler("dibc", swingset.NewIBCChannelHandler(swingsetModule))
+	app.UpgradeKeeper.SetUpgradeHandler(upgradeSwingSetStorage, func(ctx sdk.Context, plan upgradetypes.Plan) {
+		// Keep any parameters that were set, and default the rest.
+		app.SwingSetKeeper.SetParams(ctx, app.SwingSetKeeper.GetParams(ctx))
+		app.SwingSetKeeper.MigrateStorageLayout(ctx)
+	})
+
+	swingsetModule := swingset.NewAppModule(app.SwingSetKeeper, app.SwingSetController, app.SwingSetStorageFiles)
+	app.IBCPort = app.SwingSetController.GetPort("dibc")
 
 	// Create static IBC router, add transfer route, then set and seal it
 	// FIXME: Don't be confused by the name!  The port router maps *module names* (not PortIDs) to modules.
@@ -461,6 +490,7 @@ func NewAgoricApp(
 }
 
 type cosmosInitAction struct {
+	swingset.Handshake
 	Type        string `json:"type"`
 	IBCPort     int    `json:"ibcPort"`
 	StoragePort int    `json:"storagePort"`
@@ -478,38 +508,67 @@ func MakeCodecs() (codec.Marshaler, *codec.LegacyAmino) {
 // Name returns the name of the App
 func (app *GaiaApp) Name() string { return app.BaseApp.Name() }
 
+// MustInitController negotiates the controller protocol, unless the current
+// controller has already done so.  It exits the process if the controller
+// cannot be used.
 func (app *GaiaApp) MustInitController(ctx sdk.Context) {
-	if app.controllerInited {
+	if app.SwingSetController.Handshake() != nil {
 		return
 	}
-	app.controllerInited = true
+	hs, err := app.initController(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot initialize Controller", err)
+		os.Exit(1)
+	}
+	app.SwingSetController.SetHandshake(hs)
+}
 
-	// Begin initializing the controller here.
+func (app *GaiaApp) initController(ctx sdk.Context) (*swingset.Handshake, error) {
 	action := &cosmosInitAction{
+		Handshake:   swingset.NodeHandshake(),
 		Type:        "AG_COSMOS_INIT",
 		IBCPort:     app.IBCPort,
-		StoragePort: swingset.GetPort("storage"),
+		StoragePort: app.SwingSetController.GetPort("storage"),
 		ChainID:     ctx.ChainID(),
 	}
 	bz, err := json.Marshal(action)
-	if err == nil {
-		_, err = app.SwingSetKeeper.CallToController(ctx, string(bz))
+	if err != nil {
+		return nil, err
 	}
+	reply, err := app.SwingSetKeeper.CallToController(ctx, action.Type, string(bz))
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Cannot initialize Controller", err)
-		os.Exit(1)
+		return nil, err
+	}
+	hs, err := swingset.ParseHandshake(reply)
+	if err != nil {
+		return nil, err
 	}
+	if err := hs.CheckCompatible(); err != nil {
+		return nil, err
+	}
+	return hs, nil
 }
 
 // BeginBlocker application updates every begin block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 	app.MustInitController(ctx)
-	return app.mm.BeginBlock(ctx, req)
+	res := app.mm.BeginBlock(ctx, req)
+	app.SwingSetStorageWatcher.CollectEvents(res.Events)
+	return res
 }
 
 // EndBlocker application updates every end block
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	return app.mm.EndBlock(ctx, req)
+	res := app.mm.EndBlock(ctx, req)
+	app.SwingSetStorageWatcher.CollectEvents(res.Events)
+	return res
+}
+
+// DeliverTx notes the storage changes made by successful transactions
+func (app *GaiaApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	res := app.BaseApp.DeliverTx(req)
+	app.SwingSetStorageWatcher.CollectEvents(res.Events)
+	return res
 }
 
 // InitChainer application update at chain initialization
@@ -532,7 +591,12 @@ func (app *GaiaApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 func (app *GaiaApp) Commit() abci.ResponseCommit {
 	// Wrap the BaseApp's Commit method
 	res := app.BaseApp.Commit()
-	swingset.CommitBlock(app.SwingSetKeeper)
+	swingset.CommitBlock(app.SwingSetKeeper, app.SwingSetController)
+
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{})
+	app.SwingSetStorageWatcher.Commit(app.LastBlockHeight(), func(path string) ([]byte, bool) {
+		return app.SwingSetKeeper.GetStorageBytes(ctx, path)
+	})
 	return res
 }
 
@@ -625,6 +689,7 @@ func (app *GaiaApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APICo
 	authrest.RegisterTxRoutes(clientCtx, apiSvr.Router)
 	ModuleBasics.RegisterRESTRoutes(clientCtx, apiSvr.Router)
 	ModuleBasics.RegisterGRPCRoutes(apiSvr.ClientCtx, apiSvr.GRPCRouter)
+	swingsetrest.RegisterWatchRoutes(apiSvr.Router, swingset.StoreKey, app.SwingSetStorageWatcher, app.SwingSetWatchOrigins)
 
 	// register swagger API from root so that other applications can override easily
 	if apiConfig.Swagger {
@@ -632,6 +697,13 @@ func (app *GaiaApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APICo
 	}
 }
 
+// RegisterGRPCServer registers the app's gRPC services, including the
+// streaming ones that cannot be routed through ABCI queries.
+func (app *GaiaApp) RegisterGRPCServer(server gogogrpc.Server) {
+	app.BaseApp.RegisterGRPCServer(server)
+	swingset.RegisterWatchServer(server, app.SwingSetStorageWatcher)
+}
+
 // RegisterSwaggerAPI registers the swagger routes on the API router
 func RegisterSwaggerAPI(ctx client.Context, rtr *mux.Router) {
 	statikFS, err := fs.New()
@@ -665,6 +737,7 @@ func initParamsKeeper(appCodec codec.BinaryMarshaler, legacyAmino *codec.LegacyA
 	paramsKeeper.Subspace(govtypes.ModuleName).WithKeyTable(govtypes.ParamKeyTable())
 	paramsKeeper.Subspace(crisistypes.ModuleName)
 	paramsKeeper.Subspace(ibctransfertypes.ModuleName)
+	paramsKeeper.Subspace(swingset.ModuleName)
 
 	return paramsKeeper
 }
@@ -0,0 +1,406 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/cosmic-swingset/lib/fakecontroller"
+	"github.com/Agoric/cosmic-swingset/lib/jsonrpc"
+	"github.com/Agoric/cosmic-swingset/lib/replies"
+	swingset "github.com/Agoric/cosmic-swingset/x/swingset"
+)
+
+const testChainID = "agoric-test"
+
+// newTestApp starts a chain whose controller upcalls go through send.
+func newTestApp(t *testing.T, send func(context.Context, bool, string) (string, error)) *GaiaApp {
+	home, err := ioutil.TempDir("", "agoric-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(home) })
+
+	app := NewAgoricApp(
+		send, log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{},
+		home, 0, MakeEncodingConfig(),
+	)
+	stateBytes, err := json.Marshal(NewDefaultGenesisState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.InitChain(abci.RequestInitChain{
+		ChainId:         testChainID,
+		Validators:      []abci.ValidatorUpdate{},
+		ConsensusParams: simapp.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	app.Commit()
+	return app
+}
+
+// runBlock executes and commits a block at height, calling deliver in place
+// of its transactions.
+func runBlock(t *testing.T, app *GaiaApp, height int64, deliver func(sdk.Context)) {
+	header := tmproto.Header{
+		ChainID: testChainID,
+		Height:  height,
+		Time:    time.Unix(1600000000+height, 0),
+	}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	if deliver != nil {
+		deliver(app.NewContext(false, header))
+	}
+	app.EndBlock(abci.RequestEndBlock{Height: height})
+	app.Commit()
+}
+
+// deliverInbound runs a DELIVER_INBOUND message through the swingset handler.
+func deliverInbound(t *testing.T, app *GaiaApp, msg *swingset.MsgDeliverInbound) func(sdk.Context) {
+	return func(ctx sdk.Context) {
+		handler := swingset.NewHandler(app.SwingSetKeeper, app.SwingSetController)
+		if _, err := handler(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkMailboxAck checks the committed mailbox of peer.
+func checkMailboxAck(t *testing.T, app *GaiaApp, peer string, ack uint64) {
+	ctx := app.NewContext(true, tmproto.Header{})
+	value := app.SwingSetKeeper.GetStorage(ctx, "mailbox."+peer).Value
+	var mb fakecontroller.Mailbox
+	if err := json.Unmarshal([]byte(value), &mb); err != nil {
+		t.Fatalf("mailbox.%s is %q: %v", peer, value, err)
+	}
+	if mb.Ack != ack {
+		t.Errorf("mailbox.%s acks %d; expected %d", peer, mb.Ack, ack)
+	}
+}
+
+// rpcFrame is a JSON-RPC frame as the controller sees it.
+type rpcFrame struct {
+	Version string          `json:"jsonrpc"`
+	ID      *uint64         `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  *string         `json:"result,omitempty"`
+	Error   *jsonrpc.Error  `json:"error,omitempty"`
+}
+
+// rpcController serves a fake kernel over JSON-RPC, as an out-of-process
+// controller does.
+type rpcController struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	replies *replies.Table
+	fake    *fakecontroller.Controller
+}
+
+func newRPCController(conn net.Conn) *rpcController {
+	rc := &rpcController{conn: conn, replies: replies.NewTable()}
+	rc.fake = fakecontroller.New(rc.sendToGo)
+	return rc
+}
+
+func (rc *rpcController) write(f *rpcFrame) error {
+	f.Version = "2.0"
+	bz, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	rc.writeMu.Lock()
+	defer rc.writeMu.Unlock()
+	_, err = rc.conn.Write(append(bz, '\n'))
+	return err
+}
+
+func (rc *rpcController) sendToGo(port int, msg string) (string, error) {
+	params, err := json.Marshal([]interface{}{port, msg})
+	if err != nil {
+		return "", err
+	}
+	return rc.replies.Call(context.Background(), func(id int) error {
+		reqID := uint64(id)
+		return rc.write(&rpcFrame{ID: &reqID, Method: jsonrpc.MethodSendToGo, Params: params})
+	})
+}
+
+func (rc *rpcController) serve() {
+	scanner := bufio.NewScanner(rc.conn)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		var f rpcFrame
+		if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+			continue
+		}
+		if f.Method == "" {
+			if f.ID == nil {
+				continue
+			}
+			if f.Error != nil {
+				rc.replies.Reject(int(*f.ID), errors.New(f.Error.Message))
+			} else if f.Result != nil {
+				rc.replies.Resolve(int(*f.ID), *f.Result)
+			}
+			continue
+		}
+
+		// The kernel makes downcalls while handling an upcall, so handle it
+		// off the reader.
+		go func() {
+			var params []string
+			if err := json.Unmarshal(f.Params, &params); err != nil || len(params) != 1 {
+				return
+			}
+			ret, err := rc.fake.Send(context.Background(), f.ID != nil, params[0])
+			if f.ID == nil {
+				return
+			}
+			if err != nil {
+				rc.write(&rpcFrame{ID: f.ID, Error: &jsonrpc.Error{Code: jsonrpc.CodePortError, Message: err.Error()}})
+				return
+			}
+			rc.write(&rpcFrame{ID: f.ID, Result: &ret})
+		}()
+	}
+}
+
+func TestControllerOverJSONRPC(t *testing.T) {
+	goEnd, controllerEnd := net.Pipe()
+	rc := newRPCController(controllerEnd)
+	go rc.serve()
+
+	var app *GaiaApp
+	conn := jsonrpc.NewConn(goEnd, func(port int, msg string) (string, error) {
+		return app.SwingSetController.ReceiveFromController(port, msg)
+	})
+	go conn.Serve()
+	defer conn.Close()
+
+	app = newTestApp(t, conn.Send)
+	submitter := sdk.AccAddress([]byte("submitter-address-01"))
+	runBlock(t, app, 2, deliverInbound(t, app, &swingset.MsgDeliverInbound{
+		Messages:  []string{"hello", "world"},
+		Nums:      []uint64{1, 2},
+		Submitter: submitter,
+	}))
+
+	// The kernel read and wrote the mailbox through the storage port.
+	checkMailboxAck(t, app, submitter.String(), 2)
+	if height := rc.fake.CommittedHeight(); height != 2 {
+		t.Errorf("controller committed height %d", height)
+	}
+}
+
+func TestFakeController(t *testing.T) {
+	var app *GaiaApp
+	fake := fakecontroller.New(func(port int, msg string) (string, error) {
+		return app.SwingSetController.ReceiveFromController(port, msg)
+	})
+	var stored string
+	fake.Handle(fakecontroller.EndBlock, func(c *fakecontroller.Controller, action fakecontroller.Action) (string, error) {
+		if _, err := c.Storage("set", "fake.block", "ended"); err != nil {
+			return "", err
+		}
+		ret, err := c.Storage("get", "fake.block", "")
+		if err != nil {
+			return "", err
+		}
+		stored = ret
+		return c.Default(action)
+	})
+
+	app = newTestApp(t, fake.Send)
+	submitter := sdk.AccAddress([]byte("submitter-address-01"))
+	runBlock(t, app, 2, deliverInbound(t, app, &swingset.MsgDeliverInbound{
+		Messages:  []string{"hello"},
+		Nums:      []uint64{1},
+		Submitter: submitter,
+	}))
+
+	if stored != `"ended"` {
+		t.Errorf("storage get in END_BLOCK gave %s", stored)
+	}
+	ctx := app.NewContext(true, tmproto.Header{})
+	if value := app.SwingSetKeeper.GetStorage(ctx, "fake.block").Value; value != "ended" {
+		t.Errorf("committed fake.block is %q", value)
+	}
+	checkMailboxAck(t, app, submitter.String(), 1)
+
+	var types []string
+	for _, action := range fake.Actions() {
+		types = append(types, action.Type())
+	}
+	for _, want := range []string{fakecontroller.CosmosInit, fakecontroller.DeliverInbound, fakecontroller.EndBlock, fakecontroller.CommitBlock} {
+		found := false
+		for _, typ := range types {
+			found = found || typ == want
+		}
+		if !found {
+			t.Errorf("fake controller never saw %s in %q", want, types)
+		}
+	}
+}
+
+func TestInitController(t *testing.T) {
+	reply := "true"
+	var initAction cosmosInitAction
+	app := newTestApp(t, func(_ context.Context, _ bool, str string) (string, error) {
+		var action fakecontroller.Action
+		if err := json.Unmarshal([]byte(str), &action); err != nil {
+			return "", err
+		}
+		if action.Type() != fakecontroller.CosmosInit {
+			return "true", nil
+		}
+		if err := json.Unmarshal([]byte(str), &initAction); err != nil {
+			return "", err
+		}
+		return reply, nil
+	})
+
+	for _, tc := range []struct {
+		name  string
+		reply string
+		hs    *swingset.Handshake
+	}{
+		{"legacy", "true", &swingset.Handshake{}},
+		{
+			name:  "current",
+			reply: `{"protocolVersion":1,"minProtocolVersion":1,"capabilities":["kernel-export"],"encoding":"protobuf"}`,
+			hs: &swingset.Handshake{
+				ProtocolVersion: 1, MinProtocolVersion: 1,
+				Capabilities: []string{swingset.CapabilityKernelExport}, Encoding: swingset.EncodingProtobuf,
+			},
+		},
+		{"too new", `{"protocolVersion":2,"minProtocolVersion":2}`, nil},
+		{"unknown encoding", `{"protocolVersion":1,"encoding":"cbor"}`, nil},
+		{"refused", "false", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reply = tc.reply
+			initAction = cosmosInitAction{}
+			hs, err := app.initController(app.NewContext(true, tmproto.Header{ChainID: testChainID}))
+			if tc.hs == nil {
+				if err == nil {
+					t.Errorf("accepted handshake %+v", hs)
+				}
+			} else if err != nil {
+				t.Error(err)
+			} else if !reflect.DeepEqual(hs, tc.hs) {
+				t.Errorf("handshake is %+v; want %+v", hs, tc.hs)
+			}
+
+			// The node offers its own half of the handshake.
+			if !reflect.DeepEqual(initAction.Handshake, swingset.NodeHandshake()) {
+				t.Errorf("node offered %+v", initAction.Handshake)
+			}
+			if initAction.StoragePort != app.SwingSetController.GetPort("storage") || initAction.ChainID != testChainID {
+				t.Errorf("AG_COSMOS_INIT has storage port %d and chain %q", initAction.StoragePort, initAction.ChainID)
+			}
+		})
+	}
+}
+
+func TestExportKernel(t *testing.T) {
+	bundle := []byte(`{"kernel":"state"}`)
+	handshake := "true"
+	var sent []string
+	app := newTestApp(t, func(_ context.Context, _ bool, str string) (string, error) {
+		var action struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal([]byte(str), &action); err != nil {
+			return "", err
+		}
+		sent = append(sent, action.Type)
+		switch action.Type {
+		case "AG_COSMOS_INIT":
+			return handshake, nil
+		case "EXPORT_KERNEL":
+			bz, err := json.Marshal(map[string][]byte{"bundle": bundle})
+			return string(bz), err
+		}
+		return "true", nil
+	})
+
+	for _, tc := range []struct {
+		name      string
+		handshake string
+		exported  bool
+	}{
+		{"kernel export", `{"protocolVersion":1,"minProtocolVersion":1,"capabilities":["kernel-export"]}`, true},
+		{"no capability", `{"protocolVersion":1,"minProtocolVersion":1,"capabilities":["nested-upcalls"]}`, false},
+		{"legacy", "true", false},
+		{"refused", "false", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handshake = tc.handshake
+			sent = nil
+			app.SwingSetController.RequireHandshake()
+			exported, err := app.ExportAppStateAndValidators(false, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var appState map[string]json.RawMessage
+			if err := json.Unmarshal(exported.AppState, &appState); err != nil {
+				t.Fatal(err)
+			}
+			var genesis struct {
+				KernelExport *struct {
+					Hash   string `json:"hash"`
+					Bundle []byte `json:"bundle"`
+				} `json:"kernel_export"`
+			}
+			if err := json.Unmarshal(appState[swingset.ModuleName], &genesis); err != nil {
+				t.Fatal(err)
+			}
+			switch export := genesis.KernelExport; {
+			case !tc.exported && export != nil:
+				t.Errorf("exported a kernel %+v", export)
+			case tc.exported && export == nil:
+				t.Error("exported no kernel")
+			case tc.exported && (string(export.Bundle) != string(bundle) || len(export.Hash) != 64):
+				t.Errorf("exported a kernel %+v", export)
+			}
+
+			want := []string{"AG_COSMOS_INIT"}
+			if tc.exported {
+				want = append(want, "EXPORT_KERNEL")
+			}
+			if !reflect.DeepEqual(sent, want) {
+				t.Errorf("sent %q; want %q", sent, want)
+			}
+		})
+	}
+
+	// A controller that is already initialized is not asked again.
+	sent = nil
+	app.SwingSetController.SetHandshake(&swingset.Handshake{
+		ProtocolVersion: 1, Capabilities: []string{swingset.CapabilityKernelExport},
+	})
+	if _, err := app.ExportAppStateAndValidators(false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sent, []string{"EXPORT_KERNEL"}) {
+		t.Errorf("sent %q", sent)
+	}
+}
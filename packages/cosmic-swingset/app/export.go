@@ -2,7 +2,9 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"os"
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
@@ -31,6 +33,17 @@ func (app *GaiaApp) ExportAppStateAndValidators(
 		app.prepForZeroHeightGenesis(ctx, jailAllowedAddrs)
 	}
 
+	// The controller exports its kernel along with the swingset module.  A
+	// controller that cannot even be initialized has no kernel to export.
+	if app.SwingSetController.Handshake() == nil {
+		hs, err := app.initController(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot initialize Controller; exporting without kernel state:", err)
+		} else {
+			app.SwingSetController.SetHandshake(hs)
+		}
+	}
+
 	genState := app.mm.ExportGenesis(ctx, app.appCodec)
 	appState, err := json.MarshalIndent(genState, "", "  ")
 	if err != nil {
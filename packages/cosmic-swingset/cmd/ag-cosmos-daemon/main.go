@@ -0,0 +1,40 @@
+// ag-cosmos-daemon is ag-chain-cosmos as a pure Go binary.  It talks to the
+// SwingSet controller over JSON-RPC instead of running inside the Node.js
+// process, as selected by $AG_CHAIN_COSMOS_CONTROLLER:
+//
+//	AG_CHAIN_COSMOS_CONTROLLER=stdio              use stdin/stdout
+//	AG_CHAIN_COSMOS_CONTROLLER=unix:/path/to/sock listen for the controller
+//	AG_CHAIN_COSMOS_CONTROLLER=fake               use the in-process fake kernel
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Agoric/cosmic-swingset/lib/daemon"
+	"github.com/Agoric/cosmic-swingset/lib/daemon/cmd"
+	"github.com/Agoric/cosmic-swingset/lib/fakecontroller"
+	"github.com/Agoric/cosmic-swingset/lib/jsonrpc"
+)
+
+func main() {
+	spec := os.Getenv("AG_CHAIN_COSMOS_CONTROLLER")
+	if spec == "" {
+		// Without a controller we behave just like ag-cosmos-helper.
+		daemon.Run()
+		return
+	}
+
+	receiver := new(cmd.AppReceiver)
+	if spec == "fake" {
+		daemon.RunWithController(fakecontroller.New(receiver.Receive).Send, receiver)
+		return
+	}
+
+	sendToController, err := jsonrpc.Open(spec, receiver.Receive, receiver.Reconnected)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot open controller transport", err)
+		os.Exit(1)
+	}
+	daemon.RunWithController(sendToController, receiver)
+}
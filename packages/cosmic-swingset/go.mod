@@ -3,14 +3,17 @@ module github.com/Agoric/cosmic-swingset
 go 1.14
 
 require (
+	github.com/armon/go-metrics v0.3.4
 	github.com/bartekn/go-bip39 v0.0.0-20171116152956-a05967ea095d // indirect
 	github.com/confio/ics23-iavl v0.6.0 // indirect
+	github.com/confio/ics23/go v0.0.0-20200817220745-f173e6211efb
 	github.com/cosmos/cosmos-sdk v0.34.4-0.20201010134738-15324920548c
 	github.com/enigmampc/btcutil v1.0.3-0.20200723161021-e2fb6adb2a25 // indirect
 	github.com/gibson042/canonicaljson-go v1.0.3 // indirect
 	github.com/gogo/protobuf v1.3.1
 	github.com/golang/mock v1.4.4 // indirect
 	github.com/gorilla/mux v1.8.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/grpc-ecosystem/grpc-gateway v1.15.0
 	github.com/otiai10/copy v1.2.0
 	github.com/pelletier/go-toml v1.8.0 // indirect
@@ -27,6 +30,7 @@ require (
 	github.com/tendermint/go-amino v0.16.0
 	github.com/tendermint/tendermint v0.34.0-rc4.0.20201005135527-d7d0ffea13c6
 	github.com/tendermint/tm-db v0.6.2
+	golang.org/x/sys v0.0.0-20200814200057-3d37ad5750ed
 	google.golang.org/genproto v0.0.0-20200825200019-8632dd797987
 	google.golang.org/grpc v1.32.0
 	google.golang.org/protobuf v1.25.0 // indirect
@@ -69,6 +69,23 @@ private:
 
 Napi::FunctionReference NodeReplier::constructor;
 
+// replyToGo delivers a reply, and reports one that Go was not waiting for.
+static void replyToGo(int replyPort, bool isRejection, const char* str) {
+    switch (ReplyToGo(replyPort, isRejection, str)) {
+    case REPLY_OK:
+        break;
+    case REPLY_DUPLICATE:
+        std::cerr << "Go already had a reply on port " << replyPort << std::endl;
+        break;
+    case REPLY_ABANDONED:
+        std::cerr << "Go stopped waiting for the reply on port " << replyPort << std::endl;
+        break;
+    default:
+        std::cerr << "Go never asked for a reply on port " << replyPort << std::endl;
+        break;
+    }
+}
+
 static int daemonPort = -1;
 int SendToNode(int port, int replyPort, Body str) {
     //std::cerr << "Send to node port " << port << " " << str << std::endl;
@@ -94,12 +111,12 @@ int SendToNode(int port, int replyPort, Body str) {
             NodeReply ret = promise->get_future().get();
             // std::cerr << "Replying to Go with " << ret.value() << " " << ret.isRejection() << std::endl;
             if (replyPort) {
-              ReplyToGo(replyPort, ret.isRejection(), ret.value().c_str());
+              replyToGo(replyPort, ret.isRejection(), ret.value().c_str());
             }
         } catch (std::exception& e) {
             // std::cerr << "Exceptioning " << e.what() << std::endl;
             if (replyPort) {
-              ReplyToGo(replyPort, true, e.what());
+              replyToGo(replyPort, true, e.what());
             }
         }
         // std::cerr << "Thread is finished" << std::endl;
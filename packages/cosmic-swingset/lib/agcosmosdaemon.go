@@ -7,52 +7,43 @@ package main
 // inline int invokeSendFunc(sendFunc send, int port, int reply, Body str) {
 //    return send(port, reply, str);
 // }
+// /* Return codes of ReplyToGo. */
+// enum replyStatus { REPLY_OK, REPLY_ORPHANED, REPLY_DUPLICATE, REPLY_ABANDONED };
 import "C"
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 
 	"github.com/Agoric/cosmic-swingset/lib/daemon"
-	swingset "github.com/Agoric/cosmic-swingset/x/swingset"
+	"github.com/Agoric/cosmic-swingset/lib/daemon/cmd"
+	"github.com/Agoric/cosmic-swingset/lib/replies"
 )
 
-type goReturn = struct {
-	str string
-	err error
-}
-
 const SwingSetPort = 123
 
-var replies = map[int]chan goReturn{}
-var lastReply = 0
+var replyTable = replies.NewTable()
+
+// appReceiver delivers SendToGo downcalls to the running app.
+var appReceiver = new(cmd.AppReceiver)
 
 //export RunAgCosmosDaemon
 func RunAgCosmosDaemon(nodePort C.int, toNode C.sendFunc, cosmosArgs []*C.char) C.int {
 	// FIXME: Decouple the sending logic from the Cosmos app.
-	sendToNode := func(needReply bool, str string) (string, error) {
-		var rPort int
-		if needReply {
-			lastReply++
-			rPort = lastReply
-			replies[rPort] = make(chan goReturn)
-		}
-
-		// Send the message
-		C.invokeSendFunc(toNode, nodePort, C.int(rPort), C.CString(str))
+	sendToNode := func(ctx context.Context, needReply bool, str string) (string, error) {
 		if !needReply {
-			// Return immediately
-			// fmt.Fprintln(os.Stderr, "Don't wait")
+			// Send the message and return immediately.
+			C.invokeSendFunc(toNode, nodePort, C.int(0), C.CString(str))
 			return "<no-reply-requested>", nil
 		}
 
-		// Block the sending goroutine while we wait for the reply
-		// fmt.Fprintln(os.Stderr, "Waiting for", rPort)
-		ret := <-replies[rPort]
-		delete(replies, rPort)
-		// fmt.Fprintln(os.Stderr, "Woken, got", ret)
-		return ret.str, ret.err
+		// Block the sending goroutine while we wait for the reply.
+		return replyTable.Call(ctx, func(rPort int) error {
+			C.invokeSendFunc(toNode, nodePort, C.int(rPort), C.CString(str))
+			return nil
+		})
 	}
 
 	args := make([]string, len(cosmosArgs))
@@ -64,7 +55,7 @@ func RunAgCosmosDaemon(nodePort C.int, toNode C.sendFunc, cosmosArgs []*C.char)
 	go func() {
 		// We run in the background, but exit when the job is over.
 		// swingset.SendToNode("hello from Initial Go!")
-		daemon.RunWithController(sendToNode)
+		daemon.RunWithController(sendToNode, appReceiver)
 		// fmt.Fprintln(os.Stderr, "Shutting down Cosmos")
 		os.Exit(0)
 	}()
@@ -76,22 +67,25 @@ func RunAgCosmosDaemon(nodePort C.int, toNode C.sendFunc, cosmosArgs []*C.char)
 func ReplyToGo(replyPort C.int, isError C.int, str C.Body) C.int {
 	goStr := C.GoString(str)
 	// fmt.Fprintln(os.Stderr, "Reply to Go", goStr)
-	returnCh := replies[int(replyPort)]
-	if returnCh == nil {
-		// Unexpected reply.
-		// This is okay, since the caller decides whether or
-		// not she wants to listen for replies.
-		return C.int(0)
-	}
+
 	// Wake up the waiting goroutine
-	ret := goReturn{}
+	var err error
 	if int(isError) == 0 {
-		ret.str = goStr
+		err = replyTable.Resolve(int(replyPort), goStr)
 	} else {
-		ret.err = errors.New(goStr)
+		err = replyTable.Reject(int(replyPort), errors.New(goStr))
+	}
+	// The caller reports the replies that no one was waiting for.
+	switch {
+	case err == nil:
+		return C.REPLY_OK
+	case errors.Is(err, replies.ErrDuplicate):
+		return C.REPLY_DUPLICATE
+	case errors.Is(err, replies.ErrAbandoned):
+		return C.REPLY_ABANDONED
+	default:
+		return C.REPLY_ORPHANED
 	}
-	returnCh <- ret
-	return C.int(0)
 }
 
 type errorWrapper struct {
@@ -102,7 +96,7 @@ type errorWrapper struct {
 func SendToGo(port C.int, str C.Body) C.Body {
 	goStr := C.GoString(str)
 	// fmt.Fprintln(os.Stderr, "Send to Go", goStr)
-	outstr, err := swingset.ReceiveFromController(int(port), goStr)
+	outstr, err := appReceiver.Receive(int(port), goStr)
 	if err != nil {
 		// fmt.Fprintln(os.Stderr, "Cannot receive from controller", err)
 		ret := errorWrapper{
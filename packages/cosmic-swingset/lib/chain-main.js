@@ -8,6 +8,13 @@ import { launch } from './launch-chain';
 import makeBlockManager from './block-manager';
 
 const AG_COSMOS_INIT = 'AG_COSMOS_INIT';
+const EXPORT_KERNEL = 'EXPORT_KERNEL';
+const IMPORT_KERNEL = 'IMPORT_KERNEL';
+
+// The controller protocol versions we speak, negotiated at AG_COSMOS_INIT.
+const PROTOCOL_VERSION = 1;
+const MIN_PROTOCOL_VERSION = 1;
+const CAPABILITIES = ['kernel-export'];
 
 const toNumber = specimen => {
   const number = parseInt(specimen, 10);
@@ -228,6 +235,17 @@ export default async function main(progname, args, { path, env, agcc }) {
   }
 
   let blockManager;
+  let kernel;
+  async function startSwingSet() {
+    const {
+      savedChainSends: scs,
+      ...fns
+    } = await launchAndInitializeSwingSet();
+    savedChainSends = scs;
+    kernel = fns;
+    blockManager = makeBlockManager({ ...fns, flushChainSends });
+  }
+
   async function toSwingSet(action, _replier) {
     // console.log(`toSwingSet`, action);
     if (action.ibcPort) {
@@ -241,17 +259,42 @@ export default async function main(progname, args, { path, env, agcc }) {
     }
 
     if (!blockManager) {
-      const {
-        savedChainSends: scs,
-        ...fns
-      } = await launchAndInitializeSwingSet();
-      savedChainSends = scs;
-      blockManager = makeBlockManager({ ...fns, flushChainSends });
+      await startSwingSet();
     }
 
     if (action.type === AG_COSMOS_INIT) {
       // console.error('got AG_COSMOS_INIT', action);
-      return true;
+      const { protocolVersion = 0, minProtocolVersion = 0 } = action;
+      if (
+        protocolVersion < MIN_PROTOCOL_VERSION ||
+        minProtocolVersion > PROTOCOL_VERSION
+      ) {
+        throw Error(
+          `chain node speaks protocol versions ${minProtocolVersion} through ${protocolVersion}, but this kernel supports ${MIN_PROTOCOL_VERSION} through ${PROTOCOL_VERSION}`,
+        );
+      }
+      return stringify({
+        protocolVersion: PROTOCOL_VERSION,
+        minProtocolVersion: MIN_PROTOCOL_VERSION,
+        capabilities: CAPABILITIES,
+      });
+    }
+
+    if (action.type === EXPORT_KERNEL) {
+      // The chain hashes the bundle, which it receives as base64 bytes.
+      const bundle = Buffer.from(kernel.exportKernel()).toString('base64');
+      return stringify({ bundle });
+    }
+
+    if (action.type === IMPORT_KERNEL) {
+      if (!action.bundle) {
+        throw Error(`${IMPORT_KERNEL} needs an inline bundle`);
+      }
+      const exported = Buffer.from(action.bundle, 'base64').toString();
+      await kernel.importKernel(exported);
+      // Run from the imported state.
+      await startSwingSet();
+      return 'true';
     }
 
     return blockManager(action, savedChainSends);
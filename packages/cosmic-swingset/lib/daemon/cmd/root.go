@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sync"
+	"syscall"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client"
@@ -32,14 +36,78 @@ import (
 
 	gaia "github.com/Agoric/cosmic-swingset/app"
 	"github.com/Agoric/cosmic-swingset/app/params"
+	swingset "github.com/Agoric/cosmic-swingset/x/swingset"
 )
 
-// Sender is a function that sends a request to the controller.
-type Sender func(needReply bool, str string) (string, error)
+const (
+	// FlagSwingSetTranscript records all controller traffic to a file.
+	FlagSwingSetTranscript = "swingset-transcript"
+	// FlagSwingSetReplay replays a recorded transcript instead of using the controller.
+	FlagSwingSetReplay = "swingset-replay"
+	// FlagSwingSetReplayStrict fails the upcall at the first replay divergence.
+	FlagSwingSetReplayStrict = "swingset-replay-strict"
+	// FlagSwingSetValidate checks all controller traffic against its schema.
+	FlagSwingSetValidate = "swingset-validate"
+	// FlagSwingSetWatchOrigins allows browsers on other origins to watch storage.
+	FlagSwingSetWatchOrigins = "swingset-watch-origins"
+	// FlagSwingSetStorageDir makes export write SwingSet storage and the
+	// kernel bundle to files.
+	FlagSwingSetStorageDir = "swingset-storage-dir"
+)
+
+// exportStorageDir is where export writes SwingSet storage files, if set.
+var exportStorageDir string
+
+// Sender is a function that sends a request to the controller.  If needReply
+// is set, it waits for the reply until ctx is done.
+type Sender func(ctx context.Context, needReply bool, str string) (string, error)
+
+// Receiver delivers a downcall from the controller to one of the app's ports.
+type Receiver func(port int, msg string) (string, error)
+
+// AppReceiver routes controller downcalls to the app started by this process.
+// Controller transports are opened before the app exists, so they deliver
+// through an AppReceiver, which is bound once the app has been created.
+type AppReceiver struct {
+	mu          sync.RWMutex
+	receive     Receiver
+	reconnected func()
+}
+
+// Receive implements the Receiver contract.
+func (ar *AppReceiver) Receive(port int, msg string) (string, error) {
+	ar.mu.RLock()
+	receive := ar.receive
+	ar.mu.RUnlock()
+	if receive == nil {
+		return "", errors.New("no app is running to receive from the controller")
+	}
+	return receive(port, msg)
+}
+
+// Reconnected tells the app that a new controller has connected, so that it
+// repeats the protocol handshake.
+func (ar *AppReceiver) Reconnected() {
+	ar.mu.RLock()
+	reconnected := ar.reconnected
+	ar.mu.RUnlock()
+	if reconnected != nil {
+		reconnected()
+	}
+}
+
+// Bind directs downcalls to receive, and reconnections to reconnected.
+func (ar *AppReceiver) Bind(receive Receiver, reconnected func()) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	ar.receive = receive
+	ar.reconnected = reconnected
+}
 
 // NewRootCmd creates a new root command for simd. It is called once in the
-// main function.
-func NewRootCmd(sender Sender) (*cobra.Command, params.EncodingConfig) {
+// main function.  Downcalls from the controller are bound to the started app
+// through receiver, which may be nil if the controller never makes any.
+func NewRootCmd(sender Sender, receiver *AppReceiver) (*cobra.Command, params.EncodingConfig) {
 	encodingConfig := gaia.MakeEncodingConfig()
 	initClientCtx := client.Context{}.
 		WithJSONMarshaler(encodingConfig.Marshaler).
@@ -63,7 +131,7 @@ func NewRootCmd(sender Sender) (*cobra.Command, params.EncodingConfig) {
 		},
 	}
 
-	initRootCmd(sender, rootCmd, encodingConfig)
+	initRootCmd(sender, receiver, rootCmd, encodingConfig)
 
 	return rootCmd, encodingConfig
 }
@@ -81,10 +149,42 @@ func Execute(rootCmd *cobra.Command) error {
 	ctx = context.WithValue(ctx, server.ServerContextKey, server.NewDefaultContext())
 
 	executor := tmcli.PrepareBaseCmd(rootCmd, "", gaia.DefaultNodeHome)
+	defer closeAtExit()
 	return executor.ExecuteContext(ctx)
 }
 
-func initRootCmd(sender Sender, rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
+var (
+	exitClosersMu sync.Mutex
+	exitClosers   []io.Closer
+)
+
+// closeOnExit closes c once the command finishes.  The start command only
+// finishes with a signal, which server.TrapSignal answers with os.Exit, so c
+// is closed as soon as that signal arrives as well.
+func closeOnExit(c io.Closer) {
+	exitClosersMu.Lock()
+	defer exitClosersMu.Unlock()
+	if exitClosers == nil {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			closeAtExit()
+		}()
+	}
+	exitClosers = append(exitClosers, c)
+}
+
+func closeAtExit() {
+	exitClosersMu.Lock()
+	defer exitClosersMu.Unlock()
+	for _, c := range exitClosers {
+		c.Close()
+	}
+	exitClosers = exitClosers[:0]
+}
+
+func initRootCmd(sender Sender, receiver *AppReceiver, rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 	authclient.Codec = encodingConfig.Marshaler
 
 	rootCmd.AddCommand(
@@ -95,11 +195,19 @@ func initRootCmd(sender Sender, rootCmd *cobra.Command, encodingConfig params.En
 		genutilcli.ValidateGenesisCmd(gaia.ModuleBasics, encodingConfig.TxConfig),
 		AddGenesisAccountCmd(gaia.DefaultNodeHome),
 		tmcli.NewCompletionCmd(rootCmd, true),
+		swingSetSchemaCmd(),
 		testnetCmd(gaia.ModuleBasics, banktypes.GenesisBalancesIterator{}),
 		debug.Cmd(),
 	)
 
-	server.AddCommands(rootCmd, gaia.DefaultNodeHome, makeNewApp(sender), createSimappAndExport)
+	server.AddCommands(rootCmd, gaia.DefaultNodeHome, makeNewApp(sender, receiver), makeAppExporter(sender, receiver))
+	if startCmd, _, err := rootCmd.Find([]string{"start"}); err == nil {
+		addSwingSetFlags(startCmd)
+	}
+	if exportCmd, _, err := rootCmd.Find([]string{"export"}); err == nil {
+		exportCmd.Flags().StringVar(&exportStorageDir, FlagSwingSetStorageDir, "",
+			"Write SwingSet storage and the kernel bundle to files in this directory, to place beside the exported genesis.json")
+	}
 
 	// add keybase, auxiliary RPC, query, and tx child commands
 	rootCmd.AddCommand(
@@ -110,6 +218,14 @@ func initRootCmd(sender Sender, rootCmd *cobra.Command, encodingConfig params.En
 	)
 }
 
+func addSwingSetFlags(startCmd *cobra.Command) {
+	startCmd.Flags().String(FlagSwingSetTranscript, "", "Append all SwingSet controller traffic to this transcript file")
+	startCmd.Flags().String(FlagSwingSetReplay, "", "Replay this transcript file instead of calling the SwingSet controller")
+	startCmd.Flags().Bool(FlagSwingSetReplayStrict, false, "Fail at the first divergence from the replayed transcript")
+	startCmd.Flags().Bool(FlagSwingSetValidate, false, "Check all SwingSet controller messages against their schema (slow; for debugging)")
+	startCmd.Flags().StringSlice(FlagSwingSetWatchOrigins, nil, "Browser origins besides the API server's own that may watch SwingSet storage (\"*\" for any)")
+}
+
 func queryCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                        "query",
@@ -162,7 +278,7 @@ func txCommand() *cobra.Command {
 	return cmd
 }
 
-func makeNewApp(sender Sender) func(log.Logger, dbm.DB, io.Writer, servertypes.AppOptions) servertypes.Application {
+func makeNewApp(sender Sender, receiver *AppReceiver) func(log.Logger, dbm.DB, io.Writer, servertypes.AppOptions) servertypes.Application {
 	return func(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts servertypes.AppOptions) servertypes.Application {
 		var cache sdk.MultiStorePersistentCache
 
@@ -195,8 +311,33 @@ func makeNewApp(sender Sender) func(log.Logger, dbm.DB, io.Writer, servertypes.A
 			}
 		}
 
-		return gaia.NewAgoricApp(
-			sender,
+		// The app doesn't exist yet, so downcalls reach it through this closure.
+		var app *gaia.GaiaApp
+		receive := func(port int, msg string) (string, error) {
+			return app.SwingSetController.ReceiveFromController(port, msg)
+		}
+
+		send := sender
+		if path := cast.ToString(appOpts.Get(FlagSwingSetReplay)); path != "" {
+			replayer, err := swingset.NewReplayer(path, receive)
+			if err != nil {
+				panic(err)
+			}
+			replayer.Strict = cast.ToBool(appOpts.Get(FlagSwingSetReplayStrict))
+			send = replayer.Send
+		}
+		var recorder *swingset.Recorder
+		if path := cast.ToString(appOpts.Get(FlagSwingSetTranscript)); path != "" {
+			recorder, err = swingset.NewRecorder(path)
+			if err != nil {
+				panic(err)
+			}
+			send = recorder.WrapSender(send)
+			closeOnExit(recorder)
+		}
+
+		app = gaia.NewAgoricApp(
+			send,
 			logger, db, traceStore, true, skipUpgradeHeights,
 			cast.ToString(appOpts.Get(flags.FlagHome)),
 			cast.ToUint(appOpts.Get(server.FlagInvCheckPeriod)),
@@ -213,25 +354,38 @@ func makeNewApp(sender Sender) func(log.Logger, dbm.DB, io.Writer, servertypes.A
 			baseapp.SetSnapshotInterval(cast.ToUint64(appOpts.Get(server.FlagStateSyncSnapshotInterval))),
 			baseapp.SetSnapshotKeepRecent(cast.ToUint32(appOpts.Get(server.FlagStateSyncSnapshotKeepRecent))),
 		)
+		if recorder != nil {
+			app.SwingSetController.SetTranscriptRecorder(recorder)
+		}
+		app.SwingSetController.SetValidation(cast.ToBool(appOpts.Get(FlagSwingSetValidate)))
+		app.SwingSetWatchOrigins = cast.ToStringSlice(appOpts.Get(FlagSwingSetWatchOrigins))
+		if receiver != nil {
+			receiver.Bind(receive, app.SwingSetController.RequireHandshake)
+		}
+		return app
 	}
 }
 
-func createSimappAndExport(
-	logger log.Logger, db dbm.DB, traceStore io.Writer, height int64, forZeroHeight bool, jailWhiteList []string,
-) (servertypes.ExportedApp, error) {
-
-	encCfg := gaia.MakeEncodingConfig() // Ideally, we would reuse the one created by NewRootCmd.
-	encCfg.Marshaler = codec.NewProtoCodec(encCfg.InterfaceRegistry)
-	var gaiaApp *gaia.GaiaApp
-	if height != -1 {
-		gaiaApp = gaia.NewGaiaApp(logger, db, traceStore, false, map[int64]bool{}, "", uint(1), encCfg)
-
-		if err := gaiaApp.LoadHeight(height); err != nil {
-			return servertypes.ExportedApp{}, err
+// makeAppExporter returns an exporter whose app upcalls to the controller
+// through sender, so that the controller can export its kernel as well.
+func makeAppExporter(sender Sender, receiver *AppReceiver) servertypes.AppExporter {
+	return func(
+		logger log.Logger, db dbm.DB, traceStore io.Writer, height int64, forZeroHeight bool, jailWhiteList []string,
+	) (servertypes.ExportedApp, error) {
+
+		encCfg := gaia.MakeEncodingConfig() // Ideally, we would reuse the one created by NewRootCmd.
+		encCfg.Marshaler = codec.NewProtoCodec(encCfg.InterfaceRegistry)
+		gaiaApp := gaia.NewAgoricApp(sender, logger, db, traceStore, height == -1, map[int64]bool{}, "", uint(1), encCfg)
+		if height != -1 {
+			if err := gaiaApp.LoadHeight(height); err != nil {
+				return servertypes.ExportedApp{}, err
+			}
 		}
-	} else {
-		gaiaApp = gaia.NewGaiaApp(logger, db, traceStore, true, map[int64]bool{}, "", uint(1), encCfg)
-	}
+		if receiver != nil {
+			receiver.Bind(gaiaApp.SwingSetController.ReceiveFromController, gaiaApp.SwingSetController.RequireHandshake)
+		}
+		gaiaApp.SwingSetStorageFiles.ExportDir = exportStorageDir
 
-	return gaiaApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
+		return gaiaApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
+	}
 }
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	swingset "github.com/Agoric/cosmic-swingset/x/swingset"
+)
+
+// swingSetSchemaCmd returns the swingset-schema cobra Command.
+func swingSetSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swingset-schema [name]",
+		Short: "Print the JSON Schema of the SwingSet controller protocol",
+		Long: `Print the JSON Schema of every upcall action and downcall port message
+exchanged with the SwingSet controller.  If a name is given, such as
+BEGIN_BLOCK, IBC_EVENT/receivePacket or storage, print only that message's
+schema.
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var doc interface{}
+			if len(args) == 0 {
+				doc = swingset.JSONSchemaDocument()
+			} else {
+				ms := swingset.FindSchema(swingset.SchemaUpcall, args[0])
+				if ms == nil {
+					ms = swingset.FindSchema(swingset.SchemaDowncall, args[0])
+				}
+				if ms == nil {
+					return fmt.Errorf("no SwingSet message named %q", args[0])
+				}
+				doc = ms.JSONSchema()
+			}
+
+			bz, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// runSchemaCmd runs swingset-schema with args, and decodes its output.
+func runSchemaCmd(t *testing.T, args ...string) (map[string]interface{}, error) {
+	cmd := swingSetSchemaCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	return doc, nil
+}
+
+func TestSwingSetSchemaCmd(t *testing.T) {
+	doc, err := runSchemaCmd(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	definitions, _ := doc["definitions"].(map[string]interface{})
+	upcalls, _ := definitions["upcalls"].(map[string]interface{})
+	downcalls, _ := definitions["downcalls"].(map[string]interface{})
+	for _, name := range []string{"BEGIN_BLOCK", "DELIVER_INBOUND", "IBC_EVENT/receivePacket"} {
+		if upcalls[name] == nil {
+			t.Errorf("document has no upcall %s", name)
+		}
+	}
+	if downcalls["storage"] == nil || downcalls["dibc"] == nil {
+		t.Errorf("document has downcalls %v", downcalls)
+	}
+
+	for _, name := range []string{"IBC_EVENT/receivePacket", "storage"} {
+		schema, err := runSchemaCmd(t, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if schema["title"] != name {
+			t.Errorf("schema of %s has title %v", name, schema["title"])
+		}
+	}
+
+	if _, err := runSchemaCmd(t, "NO_SUCH_ACTION"); err == nil {
+		t.Error("printed the schema of NO_SUCH_ACTION")
+	}
+}
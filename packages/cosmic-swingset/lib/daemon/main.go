@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -32,7 +33,7 @@ const (
 )
 
 // DefaultController is a stub controller.
-var DefaultController = func(needReply bool, str string) (string, error) {
+var DefaultController = func(ctx context.Context, needReply bool, str string) (string, error) {
 	return "", fmt.Errorf("Controller not configured; did you mean to use `ag-chain-cosmos` instead?")
 }
 
@@ -46,16 +47,18 @@ func SetConfigDefaults(config *sdk.Config) {
 
 // Run starts the app with a stub controller.
 func Run() {
-	RunWithController(DefaultController)
+	RunWithController(DefaultController, nil)
 }
 
-// RunWithController starts the app with a custom upcall handler.
-func RunWithController(sendToController cmd.Sender) {
+// RunWithController starts the app with a custom upcall handler.  Downcalls
+// from the controller should be delivered through receiver once the app
+// starts.
+func RunWithController(sendToController cmd.Sender, receiver *cmd.AppReceiver) {
 	config := sdk.GetConfig()
 	SetConfigDefaults(config)
 	config.Seal()
 
-	rootCmd, _ := cmd.NewRootCmd(sendToController)
+	rootCmd, _ := cmd.NewRootCmd(sendToController, receiver)
 	if err := cmd.Execute(rootCmd); err != nil {
 		os.Exit(1)
 	}
@@ -0,0 +1,404 @@
+// Package fakecontroller is a scriptable, pure-Go stand-in for the SwingSet
+// kernel.
+//
+// It understands the actions that the swingset module sends (AG_COSMOS_INIT,
+// BEGIN_BLOCK, END_BLOCK, COMMIT_BLOCK, DELIVER_INBOUND, IBC_EVENT,
+// PLEASE_PROVISION, EXPORT_KERNEL and IMPORT_KERNEL), keeps each peer's
+// mailbox in the "storage" port the way the kernel does, and can issue its
+// own downcalls to the "storage" and "dibc" ports.  Tests can script canned
+// replies or custom handlers per action type.
+package fakecontroller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Action types sent by the swingset module.
+const (
+	CosmosInit      = "AG_COSMOS_INIT"
+	BeginBlock      = "BEGIN_BLOCK"
+	EndBlock        = "END_BLOCK"
+	CommitBlock     = "COMMIT_BLOCK"
+	DeliverInbound  = "DELIVER_INBOUND"
+	IBCEvent        = "IBC_EVENT"
+	PleaseProvision = "PLEASE_PROVISION"
+	ExportKernel    = "EXPORT_KERNEL"
+	ImportKernel    = "IMPORT_KERNEL"
+)
+
+// CapabilityKernelExport is advertised so that the chain exports the fake's
+// kernel state.
+const CapabilityKernelExport = "kernel-export"
+
+// ProtocolVersion is the controller protocol version the fake speaks.
+const ProtocolVersion = 1
+
+// Receiver delivers a downcall to one of the chain's ports.
+type Receiver func(port int, msg string) (string, error)
+
+// Action is a decoded upcall from the chain.
+type Action map[string]interface{}
+
+// Type returns the action's "type" field.
+func (a Action) Type() string {
+	t, _ := a["type"].(string)
+	return t
+}
+
+// Event returns the "event" field of an IBC_EVENT.
+func (a Action) Event() string {
+	e, _ := a["event"].(string)
+	return e
+}
+
+// Int returns a numeric field of the action.
+func (a Action) Int(field string) int64 {
+	n, _ := a[field].(float64)
+	return int64(n)
+}
+
+// Reply is a canned answer to an action.
+type Reply struct {
+	Value string
+	Err   error
+}
+
+// Handler processes an action in place of the default behaviour.
+type Handler func(c *Controller, action Action) (string, error)
+
+// Mailbox is the kernel's view of the messages exchanged with a peer.
+type Mailbox struct {
+	Outbox [][]interface{} `json:"outbox"`
+	Ack    uint64          `json:"ack"`
+}
+
+// Provision records a PLEASE_PROVISION request.
+type Provision struct {
+	Nickname   string   `json:"nickname"`
+	Address    string   `json:"address"`
+	PowerFlags []string `json:"powerFlags"`
+}
+
+// kernelState is the fake's kernel bundle.  Mailboxes need no export, since
+// they are kept in the chain's storage.
+type kernelState struct {
+	CommittedHeight int64       `json:"committedHeight"`
+	Provisions      []Provision `json:"provisions"`
+}
+
+// Controller is the fake kernel.  It implements the cmd.Sender contract
+// through its Send method.
+type Controller struct {
+	mu      sync.Mutex
+	receive Receiver
+
+	storagePort int
+	ibcPort     int
+
+	blockHeight     int64
+	committedHeight int64
+
+	script   map[string][]Reply
+	handlers map[string]Handler
+	inbound  []Action
+
+	actions    []Action
+	provisions []Provision
+}
+
+// New creates a fake controller that issues its downcalls through receive.
+func New(receive Receiver) *Controller {
+	return &Controller{
+		receive:  receive,
+		script:   make(map[string][]Reply),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// scriptKey names an action for scripting: its type, or "IBC_EVENT/event".
+func scriptKey(action Action) string {
+	if event := action.Event(); event != "" {
+		return action.Type() + "/" + event
+	}
+	return action.Type()
+}
+
+// Script queues canned replies for the next actions with the given key,
+// which is an action type such as "END_BLOCK" or an IBC_EVENT qualified by
+// its event, such as "IBC_EVENT/receivePacket".  Scripted replies take
+// precedence over handlers and the default behaviour.
+func (c *Controller) Script(key string, replies ...Reply) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.script[key] = append(c.script[key], replies...)
+}
+
+// Handle replaces the behaviour for actions with the given key.
+func (c *Controller) Handle(key string, handler Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[key] = handler
+}
+
+// Actions returns every action received so far.
+func (c *Controller) Actions() []Action {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Action(nil), c.actions...)
+}
+
+// Provisions returns every PLEASE_PROVISION request received so far.
+func (c *Controller) Provisions() []Provision {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Provision(nil), c.provisions...)
+}
+
+// BlockHeight returns the height of the last BEGIN_BLOCK.
+func (c *Controller) BlockHeight() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.blockHeight
+}
+
+// CommittedHeight returns the height of the last COMMIT_BLOCK.
+func (c *Controller) CommittedHeight() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.committedHeight
+}
+
+// Send implements the cmd.Sender contract.
+func (c *Controller) Send(ctx context.Context, needReply bool, str string) (string, error) {
+	var action Action
+	if err := json.Unmarshal([]byte(str), &action); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.actions = append(c.actions, action)
+	key := scriptKey(action)
+	if replies := c.script[key]; len(replies) > 0 {
+		c.script[key] = replies[1:]
+		c.mu.Unlock()
+		return replies[0].Value, replies[0].Err
+	}
+	handler := c.handlers[key]
+	if handler == nil {
+		handler = c.handlers[action.Type()]
+	}
+	c.mu.Unlock()
+
+	if handler != nil {
+		return handler(c, action)
+	}
+	return c.Default(action)
+}
+
+// Default performs the built-in behaviour for an action, so that custom
+// handlers can fall back on it.
+func (c *Controller) Default(action Action) (string, error) {
+	switch action.Type() {
+	case CosmosInit:
+		// The handshake names the chain's ports; other actions may carry a
+		// storagePort that is not the storage port's number.
+		c.mu.Lock()
+		c.storagePort = int(action.Int("storagePort"))
+		c.ibcPort = int(action.Int("ibcPort"))
+		c.mu.Unlock()
+		bz, err := json.Marshal(map[string]interface{}{
+			"protocolVersion":    ProtocolVersion,
+			"minProtocolVersion": ProtocolVersion,
+			"capabilities":       []string{CapabilityKernelExport},
+		})
+		return string(bz), err
+
+	case BeginBlock:
+		c.mu.Lock()
+		c.blockHeight = action.Int("blockHeight")
+		c.inbound = nil
+		c.mu.Unlock()
+		return "true", nil
+
+	case DeliverInbound:
+		// Like the kernel, we only process deliveries at END_BLOCK.
+		c.mu.Lock()
+		c.inbound = append(c.inbound, action)
+		c.mu.Unlock()
+		return "true", nil
+
+	case EndBlock:
+		c.mu.Lock()
+		inbound := c.inbound
+		c.inbound = nil
+		c.mu.Unlock()
+		for _, a := range inbound {
+			if err := c.deliverInbound(a); err != nil {
+				return "", err
+			}
+		}
+		return "true", nil
+
+	case CommitBlock:
+		c.mu.Lock()
+		c.committedHeight = action.Int("blockHeight")
+		c.mu.Unlock()
+		return "true", nil
+
+	case IBCEvent:
+		return "true", nil
+
+	case PleaseProvision:
+		var provision Provision
+		bz, _ := json.Marshal(action)
+		if err := json.Unmarshal(bz, &provision); err != nil {
+			return "", err
+		}
+		c.mu.Lock()
+		c.provisions = append(c.provisions, provision)
+		c.mu.Unlock()
+		return "true", nil
+
+	case ExportKernel:
+		c.mu.Lock()
+		bundle, err := json.Marshal(kernelState{
+			CommittedHeight: c.committedHeight,
+			Provisions:      c.provisions,
+		})
+		c.mu.Unlock()
+		if err != nil {
+			return "", err
+		}
+		bz, err := json.Marshal(map[string][]byte{"bundle": bundle})
+		return string(bz), err
+
+	case ImportKernel:
+		bundle, ok := action["bundle"].(string)
+		if !ok {
+			return "", errors.New("IMPORT_KERNEL needs an inline bundle")
+		}
+		var state kernelState
+		bz, err := base64.StdEncoding.DecodeString(bundle)
+		if err == nil {
+			err = json.Unmarshal(bz, &state)
+		}
+		if err != nil {
+			return "", fmt.Errorf("malformed kernel bundle: %w", err)
+		}
+		c.mu.Lock()
+		c.committedHeight = state.CommittedHeight
+		c.provisions = state.Provisions
+		c.mu.Unlock()
+		return "true", nil
+	}
+
+	return "", fmt.Errorf("%s not recognized", action.Type())
+}
+
+// deliverInbound acknowledges the highest message number from a peer.
+func (c *Controller) deliverInbound(action Action) error {
+	peer, _ := action["peer"].(string)
+	if peer == "" {
+		return errors.New("DELIVER_INBOUND without a peer")
+	}
+	mb, err := c.GetMailbox(peer)
+	if err != nil {
+		return err
+	}
+	messages, _ := action["messages"].([]interface{})
+	for _, m := range messages {
+		pair, ok := m.([]interface{})
+		if !ok || len(pair) != 2 {
+			return fmt.Errorf("malformed message %v", m)
+		}
+		num, _ := pair[0].(float64)
+		if uint64(num) > mb.Ack {
+			mb.Ack = uint64(num)
+		}
+	}
+	return c.SetMailbox(peer, mb)
+}
+
+// GetMailbox reads a peer's mailbox through the storage port.
+func (c *Controller) GetMailbox(peer string) (*Mailbox, error) {
+	ret, err := c.Storage("get", "mailbox."+peer, "")
+	if err != nil {
+		return nil, err
+	}
+	var value *string
+	if err := json.Unmarshal([]byte(ret), &value); err != nil {
+		return nil, err
+	}
+	mb := &Mailbox{Outbox: [][]interface{}{}}
+	if value == nil {
+		return mb, nil
+	}
+	if err := json.Unmarshal([]byte(*value), mb); err != nil {
+		return nil, err
+	}
+	return mb, nil
+}
+
+// SetMailbox writes a peer's mailbox through the storage port.
+func (c *Controller) SetMailbox(peer string, mb *Mailbox) error {
+	sort.Slice(mb.Outbox, func(i, j int) bool {
+		a, _ := mb.Outbox[i][0].(float64)
+		b, _ := mb.Outbox[j][0].(float64)
+		return a < b
+	})
+	bz, err := json.Marshal(mb)
+	if err != nil {
+		return err
+	}
+	_, err = c.Storage("set", "mailbox."+peer, string(bz))
+	return err
+}
+
+// Storage issues a downcall to the "storage" port.
+func (c *Controller) Storage(method, key, value string) (string, error) {
+	c.mu.Lock()
+	port := c.storagePort
+	c.mu.Unlock()
+	if port == 0 {
+		return "", errors.New("no storage port yet; AG_COSMOS_INIT must come first")
+	}
+
+	bz, err := json.Marshal(map[string]string{
+		"method": method,
+		"key":    key,
+		"value":  value,
+	})
+	if err != nil {
+		return "", err
+	}
+	return c.receive(port, string(bz))
+}
+
+// IBC issues an IBC_METHOD downcall to the "dibc" port.  The msg fields are
+// merged into {"type": "IBC_METHOD", "method": method}.
+func (c *Controller) IBC(method string, msg map[string]interface{}) (string, error) {
+	c.mu.Lock()
+	port := c.ibcPort
+	c.mu.Unlock()
+	if port == 0 {
+		return "", errors.New("no dibc port yet; AG_COSMOS_INIT must come first")
+	}
+
+	obj := map[string]interface{}{}
+	for k, v := range msg {
+		obj[k] = v
+	}
+	obj["type"] = "IBC_METHOD"
+	obj["method"] = method
+	bz, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return c.receive(port, string(bz))
+}
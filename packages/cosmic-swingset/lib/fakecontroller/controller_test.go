@@ -0,0 +1,62 @@
+package fakecontroller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func send(t *testing.T, c *Controller, action map[string]interface{}) string {
+	bz, err := json.Marshal(action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := c.Send(context.Background(), true, string(bz))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestPortsFromHandshake(t *testing.T) {
+	var ports []int
+	c := New(func(port int, msg string) (string, error) {
+		ports = append(ports, port)
+		return "null", nil
+	})
+
+	// An action other than the handshake doesn't name the storage port.
+	send(t, c, map[string]interface{}{"type": BeginBlock, "blockHeight": 1, "storagePort": 9})
+	if _, err := c.Storage("get", "a", ""); err == nil {
+		t.Error("storage downcall before the handshake succeeded")
+	}
+
+	send(t, c, map[string]interface{}{"type": CosmosInit, "storagePort": 3, "ibcPort": 4})
+	send(t, c, map[string]interface{}{"type": BeginBlock, "blockHeight": 2, "storagePort": 9})
+	if _, err := c.Storage("get", "a", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.IBC("sendPacket", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 2 || ports[0] != 3 || ports[1] != 4 {
+		t.Errorf("downcalls went to ports %v", ports)
+	}
+}
+
+func TestScriptedReplies(t *testing.T) {
+	c := New(nil)
+	failed := errors.New("scripted failure")
+	c.Script(EndBlock, Reply{Value: "first"}, Reply{Err: failed})
+
+	if ret := send(t, c, map[string]interface{}{"type": EndBlock}); ret != "first" {
+		t.Errorf("first END_BLOCK gave %q", ret)
+	}
+	if _, err := c.Send(context.Background(), true, `{"type":"END_BLOCK"}`); err != failed {
+		t.Errorf("second END_BLOCK gave %v", err)
+	}
+	if ret := send(t, c, map[string]interface{}{"type": EndBlock}); ret != "true" {
+		t.Errorf("unscripted END_BLOCK gave %q", ret)
+	}
+}
@@ -0,0 +1,319 @@
+// Package jsonrpc connects ag-chain-cosmos to an out-of-process SwingSet
+// controller.
+//
+// It is an alternative to the cgo bridge in lib/agcosmosdaemon.go.  Both sides
+// exchange newline-delimited JSON-RPC 2.0 frames over a single stream (a Unix
+// domain socket or stdin/stdout):
+//
+//	Go -> controller  {"jsonrpc":"2.0","id":1,"method":"toController","params":["<action>"]}
+//	controller -> Go  {"jsonrpc":"2.0","id":1,"result":"<reply>"}
+//
+//	controller -> Go  {"jsonrpc":"2.0","id":7,"method":"sendToGo","params":[<port>,"<msg>"]}
+//	Go -> controller  {"jsonrpc":"2.0","id":7,"result":"<reply>"}
+//
+// An upcall that doesn't need a reply is sent as a notification (without an
+// "id").  Each side numbers its own requests, so a frame with a "method" is
+// always a request from the peer, and a frame without one is a reply to us.
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Agoric/cosmic-swingset/lib/replies"
+)
+
+const (
+	// MethodToController carries an action from Go to the controller.
+	MethodToController = "toController"
+	// MethodSendToGo carries a downcall from the controller to a Go port.
+	MethodSendToGo = "sendToGo"
+
+	// Standard JSON-RPC 2.0 error codes.
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	// CodePortError reports that a port handler rejected the downcall.
+	CodePortError = -32000
+
+	// maxFrameSize bounds a single frame, which may contain a whole mailbox.
+	maxFrameSize = 256 * 1024 * 1024
+)
+
+// ErrClosed is returned for calls on a connection that has shut down.
+var ErrClosed = errors.New("controller connection closed")
+
+// Receiver handles a downcall that the controller sends to a Go port.
+type Receiver func(port int, msg string) (string, error)
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type frame struct {
+	Version string          `json:"jsonrpc"`
+	ID      *uint64         `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  *string         `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Conn is a single JSON-RPC connection to a controller.
+type Conn struct {
+	rwc     io.ReadWriteCloser
+	receive Receiver
+
+	writeMu sync.Mutex
+	w       *bufio.Writer
+
+	replies *replies.Table
+
+	// requests queues downcalls for whichever worker is handling them, and
+	// handling counts the downcalls being handled.
+	requests chan *frame
+	handling int32
+
+	mu   sync.Mutex
+	err  error
+	done chan struct{}
+}
+
+// NewConn wraps a stream to a controller.  Downcalls are dispatched to
+// receive.  Call Serve to start reading from the stream.
+func NewConn(rwc io.ReadWriteCloser, receive Receiver) *Conn {
+	return &Conn{
+		rwc:      rwc,
+		receive:  receive,
+		w:        bufio.NewWriter(rwc),
+		replies:  replies.NewTable(),
+		requests: make(chan *frame),
+		done:     make(chan struct{}),
+	}
+}
+
+// Done is closed once the connection has shut down.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Send implements the cmd.Sender contract: it delivers str to the controller
+// and, if needReply is set, blocks until the controller answers or ctx is
+// done.
+//
+// An upcall made while a downcall is being handled is taken to be nested in
+// that downcall, as the swingset Controller serializes all other upcalls.
+func (c *Conn) Send(ctx context.Context, needReply bool, str string) (string, error) {
+	params, err := json.Marshal([]interface{}{str})
+	if err != nil {
+		return "", err
+	}
+	req := frame{Version: "2.0", Method: MethodToController, Params: params}
+
+	c.mu.Lock()
+	err = c.err
+	c.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	if !needReply {
+		if err := c.write(&req); err != nil {
+			return "", err
+		}
+		return "<no-reply-requested>", nil
+	}
+
+	if atomic.LoadInt32(&c.handling) > 0 {
+		// The downcall waits for our reply, so the downcalls that the
+		// controller makes meanwhile need another worker.
+		defer c.startWorker()()
+	}
+	return c.replies.Call(ctx, func(id int) error {
+		// The call is pending now, so a shutdown from here on fails it, but
+		// one that came before would not.
+		c.mu.Lock()
+		err := c.err
+		c.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		reqID := uint64(id)
+		req.ID = &reqID
+		return c.write(&req)
+	})
+}
+
+// Serve reads frames until the stream ends, dispatching downcalls and
+// waking up pending upcalls.  Downcalls are handled one at a time, in the
+// order they arrive, by a worker other than the reader, so that Serve can
+// still read the replies to upcalls nested in a downcall.  It always returns
+// a non-nil error.
+func (c *Conn) Serve() error {
+	go c.work(nil)
+
+	scanner := bufio.NewScanner(c.rwc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFrameSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var f frame
+		if err := json.Unmarshal(line, &f); err != nil {
+			c.writeError(nil, CodeParseError, err.Error())
+			continue
+		}
+		if f.Method != "" {
+			select {
+			case c.requests <- &f:
+			case <-c.done:
+			}
+		} else {
+			c.handleReply(&f)
+		}
+	}
+
+	err := scanner.Err()
+	if err == nil {
+		err = ErrClosed
+	}
+	c.shutdown(err)
+	return err
+}
+
+// Close shuts down the connection, failing any pending upcalls.
+func (c *Conn) Close() error {
+	c.shutdown(ErrClosed)
+	return c.rwc.Close()
+}
+
+func (c *Conn) shutdown(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	c.replies.FailAll(err)
+	close(c.done)
+}
+
+// work handles queued downcalls until stop is closed or the connection shuts
+// down.  Only one worker at a time takes downcalls: a worker whose downcall
+// is waiting for a nested upcall hands over to another until the reply comes.
+func (c *Conn) work(stop <-chan struct{}) {
+	for {
+		select {
+		case f := <-c.requests:
+			atomic.AddInt32(&c.handling, 1)
+			c.handleRequest(f)
+			atomic.AddInt32(&c.handling, -1)
+		case <-stop:
+			return
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// startWorker starts a worker for the downcalls that arrive while a nested
+// upcall waits.  The returned function stops it, waiting for any downcall it
+// is handling, so that the waiting downcall resumes alone.
+func (c *Conn) startWorker() func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		c.work(stop)
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+func (c *Conn) handleRequest(f *frame) {
+	if f.Method != MethodSendToGo {
+		if f.ID != nil {
+			c.writeError(f.ID, CodeMethodNotFound, fmt.Sprintf("unknown method %q", f.Method))
+		}
+		return
+	}
+
+	var port int
+	var msg string
+	params := []interface{}{&port, &msg}
+	if err := json.Unmarshal(f.Params, &params); err != nil || len(params) != 2 {
+		if f.ID != nil {
+			c.writeError(f.ID, CodeInvalidParams, "sendToGo expects [port, msg]")
+		}
+		return
+	}
+
+	ret, err := c.receive(port, msg)
+	if f.ID == nil {
+		// A notification; nobody is listening for the result.
+		return
+	}
+	if err != nil {
+		c.writeError(f.ID, CodePortError, err.Error())
+		return
+	}
+	c.write(&frame{Version: "2.0", ID: f.ID, Result: &ret})
+}
+
+func (c *Conn) handleReply(f *frame) {
+	if f.ID == nil {
+		return
+	}
+
+	var err error
+	id := int(*f.ID)
+	switch {
+	case f.Error != nil:
+		err = c.replies.Reject(id, errors.New(f.Error.Message))
+	case f.Result != nil:
+		err = c.replies.Resolve(id, *f.Result)
+	default:
+		err = c.replies.Reject(id, &Error{Code: CodeInvalidRequest, Message: "reply has neither result nor error"})
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Controller reply error:", err)
+	}
+}
+
+func (c *Conn) writeError(id *uint64, code int, message string) error {
+	return c.write(&frame{Version: "2.0", ID: id, Error: &Error{Code: code, Message: message}})
+}
+
+func (c *Conn) write(f *frame) error {
+	bz, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if _, err = c.w.Write(append(bz, '\n')); err == nil {
+		err = c.w.Flush()
+	}
+	if err != nil {
+		c.shutdown(err)
+	}
+	return err
+}
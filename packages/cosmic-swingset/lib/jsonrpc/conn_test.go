@@ -0,0 +1,198 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// peer is the controller's end of a connection.
+type peer struct {
+	t       *testing.T
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+func (p *peer) read() frame {
+	if !p.scanner.Scan() {
+		p.t.Fatal("controller stream ended:", p.scanner.Err())
+	}
+	var f frame
+	if err := json.Unmarshal(p.scanner.Bytes(), &f); err != nil {
+		p.t.Fatal(err)
+	}
+	return f
+}
+
+func (p *peer) write(f frame) {
+	f.Version = "2.0"
+	bz, err := json.Marshal(f)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	if _, err := p.conn.Write(append(bz, '\n')); err != nil {
+		p.t.Fatal(err)
+	}
+}
+
+func sendToGo(port int, msg string) json.RawMessage {
+	params, _ := json.Marshal([]interface{}{port, msg})
+	return params
+}
+
+func TestPipelinedDowncallsInOrder(t *testing.T) {
+	goEnd, controllerEnd := net.Pipe()
+	defer controllerEnd.Close()
+
+	var inFlight int32
+	var handled []string
+	conn := NewConn(goEnd, func(port int, msg string) (string, error) {
+		if atomic.AddInt32(&inFlight, 1) != 1 {
+			t.Errorf("downcall %s ran alongside another", msg)
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		time.Sleep(time.Millisecond)
+		handled = append(handled, msg)
+		return "ok", nil
+	})
+	go conn.Serve()
+	defer conn.Close()
+
+	p := &peer{t: t, conn: controllerEnd, scanner: bufio.NewScanner(controllerEnd)}
+	const downcalls = 20
+	for i := 0; i < downcalls; i++ {
+		p.write(frame{Method: MethodSendToGo, Params: sendToGo(1, fmt.Sprint(i))})
+	}
+	lastID := uint64(1)
+	p.write(frame{ID: &lastID, Method: MethodSendToGo, Params: sendToGo(1, "last")})
+
+	if reply := p.read(); reply.ID == nil || *reply.ID != lastID {
+		t.Fatalf("unexpected downcall reply %+v", reply)
+	}
+	if len(handled) != downcalls+1 || handled[downcalls] != "last" {
+		t.Fatalf("handled %q", handled)
+	}
+	for i := 0; i < downcalls; i++ {
+		if handled[i] != fmt.Sprint(i) {
+			t.Fatalf("handled %q out of order", handled)
+		}
+	}
+}
+
+func TestNestedUpcallDuringDowncall(t *testing.T) {
+	goEnd, controllerEnd := net.Pipe()
+	defer controllerEnd.Close()
+
+	var conn *Conn
+	conn = NewConn(goEnd, func(port int, msg string) (string, error) {
+		// The handler calls back into the controller before replying.
+		ret, err := conn.Send(context.Background(), true, "nested "+msg)
+		return ret + " from port", err
+	})
+	go conn.Serve()
+	defer conn.Close()
+
+	p := &peer{t: t, conn: controllerEnd, scanner: bufio.NewScanner(controllerEnd)}
+	downcallID := uint64(7)
+	p.write(frame{ID: &downcallID, Method: MethodSendToGo, Params: sendToGo(1, "downcall")})
+
+	upcall := p.read()
+	if upcall.Method != MethodToController || upcall.ID == nil {
+		t.Fatalf("expected a nested upcall, got %+v", upcall)
+	}
+	result := "reply"
+	p.write(frame{ID: upcall.ID, Result: &result})
+
+	reply := p.read()
+	if reply.ID == nil || *reply.ID != downcallID || reply.Result == nil || *reply.Result != "reply from port" {
+		t.Fatalf("unexpected downcall reply %+v", reply)
+	}
+}
+
+func TestDowncallDuringNestedUpcall(t *testing.T) {
+	goEnd, controllerEnd := net.Pipe()
+	defer controllerEnd.Close()
+
+	var conn *Conn
+	conn = NewConn(goEnd, func(port int, msg string) (string, error) {
+		if port == 2 {
+			// The inner downcall answers directly.
+			return "inner " + msg, nil
+		}
+		return conn.Send(context.Background(), true, msg)
+	})
+	go conn.Serve()
+	defer conn.Close()
+
+	p := &peer{t: t, conn: controllerEnd, scanner: bufio.NewScanner(controllerEnd)}
+	outerID, innerID := uint64(1), uint64(2)
+	p.write(frame{ID: &outerID, Method: MethodSendToGo, Params: sendToGo(1, "outer")})
+
+	// While handling the nested upcall, the controller makes a downcall of
+	// its own, which must not wait for the outer one.
+	upcall := p.read()
+	if upcall.Method != MethodToController {
+		t.Fatalf("expected a nested upcall, got %+v", upcall)
+	}
+	p.write(frame{ID: &innerID, Method: MethodSendToGo, Params: sendToGo(2, "downcall")})
+	inner := p.read()
+	if inner.ID == nil || *inner.ID != innerID || inner.Result == nil || *inner.Result != "inner downcall" {
+		t.Fatalf("unexpected inner downcall reply %+v", inner)
+	}
+
+	result := "upcall reply"
+	p.write(frame{ID: upcall.ID, Result: &result})
+	outer := p.read()
+	if outer.ID == nil || *outer.ID != outerID || outer.Result == nil || *outer.Result != result {
+		t.Fatalf("unexpected outer downcall reply %+v", outer)
+	}
+}
+
+func TestSendAfterClose(t *testing.T) {
+	goEnd, controllerEnd := net.Pipe()
+	defer controllerEnd.Close()
+	conn := NewConn(goEnd, nil)
+	go conn.Serve()
+	conn.Close()
+
+	if _, err := conn.Send(context.Background(), true, "late"); !errors.Is(err, ErrClosed) {
+		t.Errorf("conn.Send after Close gave %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(listener, nil)
+	go server.Serve()
+
+	send := func(msg string) {
+		done := make(chan error, 1)
+		go func() {
+			_, err := server.Send(context.Background(), true, msg)
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if !errors.Is(err, ErrClosed) {
+				t.Errorf("server.Send of %q gave %v", msg, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("server.Send of %q still blocks after Close", msg)
+		}
+	}
+
+	// Nobody connects, so an upcall waits until the server closes.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		server.Close()
+	}()
+	send("waiting")
+	send("late")
+}
@@ -0,0 +1,218 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// Server accepts controller connections on a listener, one at a time, so that
+// the controller can be restarted without restarting the chain node.
+type Server struct {
+	listener net.Listener
+	receive  Receiver
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	conn   *Conn
+	closed bool
+
+	// OnConnect, if set, is called whenever a controller connects, before any
+	// upcalls are sent to it.
+	OnConnect func()
+}
+
+// Listen creates a Server listening on a Unix domain socket at path.  A stale
+// socket file left over from a previous run is removed first.
+func Listen(path string, receive Receiver) (*Server, error) {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return NewServer(listener, receive), nil
+}
+
+// NewServer creates a Server for an existing listener.
+func NewServer(listener net.Listener, receive Receiver) *Server {
+	s := &Server{
+		listener: listener,
+		receive:  receive,
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Serve accepts controller connections until the listener is closed.  A new
+// connection replaces the previous one, whose pending upcalls fail.
+func (s *Server) Serve() error {
+	for {
+		rwc, err := s.listener.Accept()
+		if err != nil {
+			return err
+		}
+		conn := NewConn(rwc, s.receive)
+		if s.OnConnect != nil {
+			s.OnConnect()
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return ErrClosed
+		}
+		old := s.conn
+		s.conn = conn
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		if old != nil {
+			old.Close()
+		}
+		go func() {
+			conn.Serve()
+			s.mu.Lock()
+			if s.conn == conn {
+				s.conn = nil
+			}
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Close stops accepting connections and closes the current one.  Later
+// upcalls, and any waiting for a controller to connect, fail with ErrClosed.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
+	return s.listener.Close()
+}
+
+// Send implements the cmd.Sender contract, waiting for a controller to
+// connect if none is currently attached.
+func (s *Server) Send(ctx context.Context, needReply bool, str string) (string, error) {
+	conn, err := s.waitForConn(ctx)
+	if err != nil {
+		return "", err
+	}
+	return conn.Send(ctx, needReply, str)
+}
+
+func (s *Server) waitForConn(ctx context.Context) (*Conn, error) {
+	s.mu.Lock()
+	conn, closed := s.conn, s.closed
+	s.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+	if conn != nil {
+		return conn, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Wake up the waiters below if ctx finishes first.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.cond.Broadcast()
+			s.mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.conn == nil {
+		if s.closed {
+			return nil, ErrClosed
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("waiting for controller to connect: %w", err)
+		}
+		s.cond.Wait()
+	}
+	return s.conn, nil
+}
+
+type stdio struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// Open starts a controller transport described by spec, which is either
+// "stdio" or a Unix socket path optionally prefixed with "unix:".  It returns
+// a function with the cmd.Sender signature.  onConnect, if not nil, is called
+// whenever a new controller connects to the socket.
+//
+// For "stdio", file descriptor 1 is redirected to stderr so that output from
+// the rest of the process doesn't corrupt the protocol stream.
+func Open(spec string, receive Receiver, onConnect func()) (func(context.Context, bool, string) (string, error), error) {
+	switch {
+	case spec == "stdio":
+		out, err := redirectStdout()
+		if err != nil {
+			return nil, err
+		}
+		conn := NewConn(stdio{os.Stdin, out}, receive)
+		go func() {
+			err := conn.Serve()
+			fmt.Fprintln(os.Stderr, "Controller disconnected:", err)
+			os.Exit(1)
+		}()
+		return conn.Send, nil
+
+	case spec != "":
+		server, err := Listen(strings.TrimPrefix(spec, "unix:"), receive)
+		if err != nil {
+			return nil, err
+		}
+		server.OnConnect = onConnect
+		go func() {
+			err := server.Serve()
+			fmt.Fprintln(os.Stderr, "Controller listener stopped:", err)
+		}()
+		return server.Send, nil
+
+	default:
+		return nil, fmt.Errorf("empty controller transport; use \"stdio\" or \"unix:PATH\"")
+	}
+}
+
+// redirectStdout points file descriptor 1 at stderr, and returns a file for
+// the original stdout.  Swapping os.Stdout alone would miss writers that
+// already hold it, or that write to the descriptor directly, such as cgo.
+func redirectStdout() (*os.File, error) {
+	fd, err := unix.Dup(int(os.Stdout.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	unix.CloseOnExec(fd)
+	if err := unix.Dup2(int(os.Stderr.Fd()), int(os.Stdout.Fd())); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), "controller"), nil
+}
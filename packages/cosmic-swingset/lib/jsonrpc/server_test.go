@@ -0,0 +1,45 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// TestRedirectStdout runs itself in a subprocess, since it moves the
+// process's stdout.
+func TestRedirectStdout(t *testing.T) {
+	if os.Getenv("JSONRPC_REDIRECT_STDOUT") == "1" {
+		out, err := redirectStdout()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		// Neither a captured os.Stdout nor the bare descriptor reach the
+		// protocol stream any more.
+		fmt.Fprintln(os.Stdout, "stray os.Stdout")
+		syscall.Write(1, []byte("stray fd 1\n"))
+		fmt.Fprintln(out, "protocol")
+		os.Exit(0)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRedirectStdout$")
+	cmd.Env = append(os.Environ(), "JSONRPC_REDIRECT_STDOUT=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err, stderr.String())
+	}
+	if stdout.String() != "protocol\n" {
+		t.Errorf("protocol stream is %q", stdout.String())
+	}
+	for _, stray := range []string{"stray os.Stdout", "stray fd 1"} {
+		if !strings.Contains(stderr.String(), stray) {
+			t.Errorf("stderr %q is missing %q", stderr.String(), stray)
+		}
+	}
+}
@@ -74,7 +74,7 @@ export async function launch(
 
   const tempdir = path.resolve(kernelStateDBDir, 'check-lmdb-tempdir');
   const { openSwingStore } = getBestSwingStore(tempdir);
-  const { storage, commit } = openSwingStore(kernelStateDBDir);
+  const { storage, commit, close } = openSwingStore(kernelStateDBDir);
 
   function bridgeOutbound(dstID, obj) {
     // console.error('would outbound bridge', dstID, obj);
@@ -134,6 +134,32 @@ export async function launch(
     await controller.run();
   }
 
+  // The kernel state, without our record of the blocks that computed it,
+  // which means nothing to another chain.
+  function exportKernel() {
+    const entries = [];
+    for (const key of storage.getKeys('', '')) {
+      if (key !== SWING_STORE_META_KEY) {
+        entries.push([key, storage.get(key)]);
+      }
+    }
+    return JSON.stringify(entries);
+  }
+
+  // Replace the kernel state with an export, and close the store.  The
+  // kernel must be launched again to run from it.
+  async function importKernel(exported) {
+    const entries = JSON.parse(exported);
+    for (const key of [...storage.getKeys('', '')]) {
+      storage.delete(key);
+    }
+    for (const [key, value] of entries) {
+      storage.set(key, value);
+    }
+    await commit();
+    close();
+  }
+
   const [savedHeight, savedActions, savedChainSends] = JSON.parse(
     storage.get(SWING_STORE_META_KEY) || '[0, [], []]',
   );
@@ -144,6 +170,8 @@ export async function launch(
     beginBlock,
     saveChainState,
     saveOutsideState,
+    exportKernel,
+    importKernel,
     savedHeight,
     savedActions,
     savedChainSends,
@@ -0,0 +1,174 @@
+// Package replies tracks upcalls to the SwingSet controller that are waiting
+// for an answer.
+//
+// A Table is safe for concurrent use, so several goroutines (a block being
+// executed, gRPC queries, ...) can each wait for their own reply.  Every call
+// can carry its own deadline or be cancelled through its context.  A reply
+// that nobody is waiting for is reported as a *ReplyError rather than being
+// silently dropped.
+package replies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// recentLimit is how many settled reply IDs we remember in order to tell a
+// duplicate or late reply apart from one that was never requested.
+const recentLimit = 1024
+
+var (
+	// ErrOrphaned is a reply for an ID that was never handed out.
+	ErrOrphaned = errors.New("orphaned reply")
+	// ErrDuplicate is a second reply for an ID that was already answered.
+	ErrDuplicate = errors.New("duplicate reply")
+	// ErrAbandoned is a reply that arrived after its caller stopped waiting.
+	ErrAbandoned = errors.New("abandoned reply")
+)
+
+// ReplyError describes a reply that could not be delivered.
+type ReplyError struct {
+	ID     int
+	Reason error
+}
+
+func (e *ReplyError) Error() string {
+	return fmt.Sprintf("%s to reply port %d", e.Reason, e.ID)
+}
+
+// Unwrap lets errors.Is match the Reason.
+func (e *ReplyError) Unwrap() error {
+	return e.Reason
+}
+
+type result struct {
+	str string
+	err error
+}
+
+// Table allocates reply IDs and routes replies to their waiting callers.
+type Table struct {
+	mu      sync.Mutex
+	lastID  int
+	pending map[int]chan result
+
+	// recent remembers why each recently settled ID is no longer pending.
+	recent      map[int]error
+	recentOrder []int
+}
+
+// NewTable returns an empty Table.  Reply IDs start at 1, so that 0 can mean
+// "no reply requested".
+func NewTable() *Table {
+	return &Table{
+		pending: make(map[int]chan result),
+		recent:  make(map[int]error),
+	}
+}
+
+// Call allocates a reply ID, passes it to send, and then waits for the reply
+// or for ctx to finish.  A nil ctx waits forever.
+func (t *Table) Call(ctx context.Context, send func(id int) error) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t.mu.Lock()
+	t.lastID++
+	id := t.lastID
+	ch := make(chan result, 1)
+	t.pending[id] = ch
+	t.mu.Unlock()
+
+	if err := send(id); err != nil {
+		t.settle(id, ErrAbandoned)
+		return "", err
+	}
+
+	select {
+	case ret := <-ch:
+		return ret.str, ret.err
+	case <-ctx.Done():
+		if t.settle(id, ErrAbandoned) == nil {
+			// The reply raced in just before we gave up.
+			ret := <-ch
+			return ret.str, ret.err
+		}
+		return "", fmt.Errorf("waiting for reply port %d: %w", id, ctx.Err())
+	}
+}
+
+// Resolve delivers a successful reply.
+func (t *Table) Resolve(id int, str string) error {
+	return t.deliver(id, result{str: str})
+}
+
+// Reject delivers an error reply.
+func (t *Table) Reject(id int, err error) error {
+	return t.deliver(id, result{err: err})
+}
+
+// Pending returns the number of calls still waiting for a reply.
+func (t *Table) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pending)
+}
+
+// FailAll rejects every pending call with err, such as when the controller
+// goes away.
+func (t *Table) FailAll(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, ch := range t.pending {
+		ch <- result{err: fmt.Errorf("controller did not reply: %w", err)}
+		delete(t.pending, id)
+		t.remember(id, ErrAbandoned)
+	}
+}
+
+func (t *Table) deliver(id int, ret result) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ch, ok := t.pending[id]
+	if !ok {
+		reason, seen := t.recent[id]
+		switch {
+		case !seen:
+			reason = ErrOrphaned
+		case reason == nil:
+			reason = ErrDuplicate
+		}
+		return &ReplyError{ID: id, Reason: reason}
+	}
+	delete(t.pending, id)
+	t.remember(id, nil)
+	ch <- ret
+	return nil
+}
+
+// settle removes id from the pending set if it is still there, recording
+// reason.  It returns nil if the ID had already been settled.
+func (t *Table) settle(id int, reason error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.pending[id]; !ok {
+		return nil
+	}
+	delete(t.pending, id)
+	t.remember(id, reason)
+	return reason
+}
+
+// remember records a settled ID, forgetting the oldest ones beyond
+// recentLimit.  A nil reason means the ID was answered normally.
+func (t *Table) remember(id int, reason error) {
+	t.recent[id] = reason
+	t.recentOrder = append(t.recentOrder, id)
+	if len(t.recentOrder) > recentLimit {
+		delete(t.recent, t.recentOrder[0])
+		t.recentOrder = t.recentOrder[1:]
+	}
+}
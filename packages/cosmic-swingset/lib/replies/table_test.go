@@ -0,0 +1,151 @@
+package replies
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// call starts a Call on t, and returns its ID and a channel with its outcome.
+func call(t *testing.T, table *Table, ctx context.Context) (int, <-chan result) {
+	ids := make(chan int, 1)
+	done := make(chan result, 1)
+	go func() {
+		str, err := table.Call(ctx, func(id int) error {
+			ids <- id
+			return nil
+		})
+		done <- result{str, err}
+	}()
+	select {
+	case id := <-ids:
+		return id, done
+	case <-time.After(5 * time.Second):
+		t.Fatal("Call never sent")
+		return 0, nil
+	}
+}
+
+func wait(t *testing.T, done <-chan result) result {
+	select {
+	case ret := <-done:
+		return ret
+	case <-time.After(5 * time.Second):
+		t.Fatal("Call never returned")
+		return result{}
+	}
+}
+
+func checkReplyError(t *testing.T, err error, id int, reason error) {
+	t.Helper()
+	var re *ReplyError
+	if !errors.As(err, &re) || re.ID != id || !errors.Is(err, reason) {
+		t.Errorf("reply to %d gave %v; want %v", id, err, reason)
+	}
+}
+
+func TestResolveAndReject(t *testing.T) {
+	table := NewTable()
+	id, done := call(t, table, nil)
+	if err := table.Resolve(id, "ok"); err != nil {
+		t.Fatal(err)
+	}
+	if ret := wait(t, done); ret.str != "ok" || ret.err != nil {
+		t.Errorf("Call returned %q, %v", ret.str, ret.err)
+	}
+
+	id, done = call(t, table, nil)
+	rejection := errors.New("rejected")
+	if err := table.Reject(id, rejection); err != nil {
+		t.Fatal(err)
+	}
+	if ret := wait(t, done); ret.err != rejection {
+		t.Errorf("Call returned %q, %v", ret.str, ret.err)
+	}
+	if pending := table.Pending(); pending != 0 {
+		t.Errorf("%d calls still pending", pending)
+	}
+}
+
+func TestOrphanedReply(t *testing.T) {
+	table := NewTable()
+	checkReplyError(t, table.Resolve(1, "never asked"), 1, ErrOrphaned)
+
+	// IDs beyond those handed out are orphans too.
+	id, done := call(t, table, nil)
+	checkReplyError(t, table.Resolve(id+1, "never asked"), id+1, ErrOrphaned)
+	table.Resolve(id, "ok")
+	wait(t, done)
+}
+
+func TestDuplicateReply(t *testing.T) {
+	table := NewTable()
+	id, done := call(t, table, nil)
+	if err := table.Resolve(id, "first"); err != nil {
+		t.Fatal(err)
+	}
+	checkReplyError(t, table.Resolve(id, "second"), id, ErrDuplicate)
+	checkReplyError(t, table.Reject(id, errors.New("third")), id, ErrDuplicate)
+	if ret := wait(t, done); ret.str != "first" {
+		t.Errorf("Call returned %q", ret.str)
+	}
+}
+
+func TestAbandonedReply(t *testing.T) {
+	table := NewTable()
+	ctx, cancel := context.WithCancel(context.Background())
+	id, done := call(t, table, ctx)
+	cancel()
+	if ret := wait(t, done); !errors.Is(ret.err, context.Canceled) {
+		t.Errorf("cancelled Call returned %q, %v", ret.str, ret.err)
+	}
+	checkReplyError(t, table.Resolve(id, "late"), id, ErrAbandoned)
+
+	// A call whose request was never sent is abandoned as well.
+	sendErr := errors.New("cannot send")
+	var unsent int
+	if _, err := table.Call(nil, func(id int) error {
+		unsent = id
+		return sendErr
+	}); err != sendErr {
+		t.Errorf("unsent Call returned %v", err)
+	}
+	checkReplyError(t, table.Resolve(unsent, "late"), unsent, ErrAbandoned)
+
+	// So are the calls failed when the controller goes away.
+	id, done = call(t, table, nil)
+	table.FailAll(errors.New("gone"))
+	if ret := wait(t, done); ret.err == nil {
+		t.Error("FailAll left a Call succeeding")
+	}
+	checkReplyError(t, table.Resolve(id, "late"), id, ErrAbandoned)
+}
+
+func TestDeadline(t *testing.T) {
+	table := NewTable()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	id, done := call(t, table, ctx)
+	if ret := wait(t, done); !errors.Is(ret.err, context.DeadlineExceeded) {
+		t.Errorf("Call past its deadline returned %q, %v", ret.str, ret.err)
+	}
+	if pending := table.Pending(); pending != 0 {
+		t.Errorf("%d calls still pending", pending)
+	}
+	checkReplyError(t, table.Resolve(id, "late"), id, ErrAbandoned)
+}
+
+func TestForgetsOldReplies(t *testing.T) {
+	table := NewTable()
+	first, done := call(t, table, nil)
+	table.Resolve(first, "ok")
+	wait(t, done)
+	for i := 0; i < recentLimit; i++ {
+		id, done := call(t, table, nil)
+		table.Resolve(id, "ok")
+		wait(t, done)
+	}
+	// The first ID is too old to tell from one never handed out.
+	checkReplyError(t, table.Resolve(first, "again"), first, ErrOrphaned)
+}
@@ -22,6 +22,8 @@ var (
 	NewKeys              = types.NewKeys
 	ModuleCdc            = types.ModuleCdc
 	RegisterCodec        = types.RegisterCodec
+	RegisterWatchServer  = types.RegisterWatchServer
+	DefaultParams        = types.DefaultParams
 )
 
 type (
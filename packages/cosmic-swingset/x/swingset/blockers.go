@@ -1,8 +1,9 @@
 package swingset
 
 import (
-	"encoding/json"
+	"time"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -29,41 +30,47 @@ type commitBlockAction struct {
 	BlockTime   int64  `json:"blockTime"`
 }
 
-func BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock, keeper Keeper) error {
+func BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock, keeper Keeper, controller *Controller) error {
+	blockGasMeter := newBlockGasMeter(keeper.GetParams(ctx))
+	controller.setBlockGasMeter(blockGasMeter)
+	ctx = WithStorageGasMeter(ctx, blockGasMeter)
+
 	action := &beginBlockAction{
 		Type:        "BEGIN_BLOCK",
-		StoragePort: GetPort("storage"),
+		StoragePort: controller.GetPort("storage"),
 		BlockHeight: ctx.BlockHeight(),
 		BlockTime:   ctx.BlockTime().Unix(),
 		ChainID:     ctx.ChainID(),
 	}
-	b, err := json.Marshal(action)
+	b, err := controller.EncodeAction(action)
 	if err != nil {
 		return sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	_, err = keeper.CallToController(ctx, string(b))
+	_, err = keeper.CallToController(ctx, action.Type, b)
 
 	// fmt.Fprintln(os.Stderr, "Returned from SwingSet", out, err)
 	return err
 }
 
-var endBlockHeight int64
-var endBlockTime int64
+func EndBlock(ctx sdk.Context, req abci.RequestEndBlock, keeper Keeper, controller *Controller) ([]abci.ValidatorUpdate, error) {
+	defer telemetry.ModuleMeasureSince(ModuleName, time.Now(), telemetry.MetricKeyEndBlocker)
+	if blockGasMeter := controller.getBlockGasMeter(); blockGasMeter != nil {
+		ctx = WithStorageGasMeter(ctx, blockGasMeter)
+	}
 
-func EndBlock(ctx sdk.Context, req abci.RequestEndBlock, keeper Keeper) ([]abci.ValidatorUpdate, error) {
 	action := &endBlockAction{
 		Type:        "END_BLOCK",
 		BlockHeight: ctx.BlockHeight(),
 		BlockTime:   ctx.BlockTime().Unix(),
-		StoragePort: GetPort("storage"),
+		StoragePort: controller.GetPort("storage"),
 	}
-	b, err := json.Marshal(action)
+	b, err := controller.EncodeAction(action)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	_, err = keeper.CallToController(ctx, string(b))
+	_, err = keeper.CallToController(ctx, action.Type, b)
 
 	// fmt.Fprintln(os.Stderr, "Returned from SwingSet", out, err)
 	if err != nil {
@@ -73,26 +80,27 @@ func EndBlock(ctx sdk.Context, req abci.RequestEndBlock, keeper Keeper) ([]abci.
 	}
 
 	// Save our EndBlock status.
-	endBlockHeight = ctx.BlockHeight()
-	endBlockTime = ctx.BlockTime().Unix()
+	controller.endBlockHeight = ctx.BlockHeight()
+	controller.endBlockTime = ctx.BlockTime().Unix()
 
 	return []abci.ValidatorUpdate{}, nil
 }
 
-func CommitBlock(keeper Keeper) error {
+func CommitBlock(keeper Keeper, controller *Controller) error {
 	action := &commitBlockAction{
 		Type:        "COMMIT_BLOCK",
-		BlockHeight: endBlockHeight,
-		BlockTime:   endBlockTime,
+		BlockHeight: controller.endBlockHeight,
+		BlockTime:   controller.endBlockTime,
 	}
-	committedHeight = endBlockHeight
+	controller.committedHeight = controller.endBlockHeight
+	defer controller.reportBlockDowncalls()
 
-	b, err := json.Marshal(action)
+	b, err := controller.EncodeAction(action)
 	if err != nil {
 		return sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	_, err = keeper.CallToController(sdk.Context{}, string(b))
+	_, err = keeper.CallToController(sdk.Context{}, action.Type, b)
 
 	// fmt.Fprintln(os.Stderr, "Returned from SwingSet", out, err)
 	if err != nil {
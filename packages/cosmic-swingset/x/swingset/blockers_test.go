@@ -0,0 +1,40 @@
+package swingset
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCommitBlock(t *testing.T) {
+	controller := NewController()
+	var keeper Keeper
+	var sent []string
+	var types []string
+	keeper.CallToController = func(ctx sdk.Context, actionType, str string) (string, error) {
+		types = append(types, actionType)
+		return controller.Call(ctx, &keeper, actionType, str, func(ctx context.Context, needReply bool, str string) (string, error) {
+			if ctx == nil {
+				t.Error("upcall has a nil context")
+			}
+			sent = append(sent, str)
+			return "true", nil
+		})
+	}
+	controller.endBlockHeight = 7
+	controller.endBlockTime = 1600000000
+
+	if err := CommitBlock(keeper, controller); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || len(types) != 1 || types[0] != "COMMIT_BLOCK" {
+		t.Errorf("sent %q of types %q, want one COMMIT_BLOCK", sent, types)
+	}
+	if controller.committedHeight != 7 {
+		t.Errorf("committed height %d, want 7", controller.committedHeight)
+	}
+	if depth := controller.Depth(); depth != 0 {
+		t.Errorf("%d upcalls left in progress", depth)
+	}
+}
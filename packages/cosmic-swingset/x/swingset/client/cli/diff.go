@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+const (
+	flagDiffFrom = "from"
+	flagDiffTo   = "to"
+)
+
+// StorageDiff lists the storage changes between two heights.
+type StorageDiff struct {
+	From    int64            `json:"from" yaml:"from"`
+	To      int64            `json:"to" yaml:"to"`
+	Added   []StorageDiffKey `json:"added" yaml:"added"`
+	Removed []StorageDiffKey `json:"removed" yaml:"removed"`
+	Changed []StorageDiffKey `json:"changed" yaml:"changed"`
+}
+
+// StorageDiffKey is a storage path and its values at the two heights.
+type StorageDiffKey struct {
+	Path string `json:"path" yaml:"path"`
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	To   string `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// GetCmdStorageDiff compares storage at two heights
+func GetCmdStorageDiff(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage-diff [path]",
+		Short: "list storage added, removed and changed at or below path between two heights",
+		Long: `List storage added, removed and changed at or below path between two heights.
+Both heights must still be kept by the node, since it reads the versioned store.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			from, _ := cmd.Flags().GetInt64(flagDiffFrom)
+			to, _ := cmd.Flags().GetInt64(flagDiffTo)
+			if from <= 0 {
+				return fmt.Errorf("--%s is required", flagDiffFrom)
+			}
+			path := types.PathString(storagePathArg(args))
+
+			before, err := querySubtree(cctx.WithHeight(from), path)
+			if err != nil {
+				return err
+			}
+			// A zero height is the latest.
+			after, err := querySubtree(cctx.WithHeight(to), path)
+			if err != nil {
+				return err
+			}
+
+			diff := StorageDiff{From: from, To: to}
+			for p, value := range after {
+				old, ok := before[p]
+				switch {
+				case !ok:
+					diff.Added = append(diff.Added, StorageDiffKey{Path: p, To: value})
+				case old != value:
+					diff.Changed = append(diff.Changed, StorageDiffKey{Path: p, From: old, To: value})
+				}
+			}
+			for p, old := range before {
+				if _, ok := after[p]; !ok {
+					diff.Removed = append(diff.Removed, StorageDiffKey{Path: p, From: old})
+				}
+			}
+			for _, keys := range [][]StorageDiffKey{diff.Added, diff.Removed, diff.Changed} {
+				sort.Slice(keys, func(i, j int) bool { return keys[i].Path < keys[j].Path })
+			}
+			return cctx.PrintOutputLegacy(diff)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().Int64(flagDiffFrom, 0, "Height to compare from")
+	cmd.Flags().Int64(flagDiffTo, 0, "Height to compare to (default: latest)")
+	return cmd
+}
+
+// querySubtree reads the storage at and below path, at the height of cctx,
+// directly from the versioned store.
+func querySubtree(cctx client.Context, path string) (map[string]string, error) {
+	res, err := cctx.QueryABCI(abci.RequestQuery{
+		Path: fmt.Sprintf("/store/%s/subspace", types.StoreKey),
+		Data: types.DataKey(path),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pairs kv.Pairs
+	if err := pairs.Unmarshal(res.Value); err != nil {
+		return nil, err
+	}
+
+	subtree := make(map[string]string, len(pairs.Pairs))
+	for _, pair := range pairs.Pairs {
+		p, err := types.DecodePath(pair.Key[len(types.DataPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		value, err := types.ParseStoreValue(pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("storage %s: %w", p, err)
+		}
+		subtree[p] = string(value)
+	}
+	return subtree, nil
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	ics23 "github.com/confio/ics23/go"
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/light"
+	lighthttp "github.com/tendermint/tendermint/light/provider/http"
+	lightdb "github.com/tendermint/tendermint/light/store/db"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+const (
+	flagProve       = "prove"
+	flagTrustedNode = "trusted-node"
+	flagTrustHeight = "trust-height"
+	flagTrustHash   = "trust-hash"
+	flagTrustPeriod = "trust-period"
+)
+
+// addProofFlags adds the flags that request verified query results.
+func addProofFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagProve, false, "Verify the result with Merkle proofs against the app hash of a header signed by the validators")
+	cmd.Flags().String(flagTrustedNode, "", "<host>:<port> of a node trusted for validator sets, or the light client's witness with --trust-height")
+	cmd.Flags().Int64(flagTrustHeight, 0, "Height of a trusted header from which a light client verifies the validators")
+	cmd.Flags().String(flagTrustHash, "", "Hex hash of the trusted header at --trust-height")
+	cmd.Flags().Duration(flagTrustPeriod, 168*time.Hour, "How long after its time the light client trusts a header, which must be less than the unbonding period")
+}
+
+// storageProver reads SwingSet store entries with proofs, and checks them
+// against the app hash in the verified header of the next block.
+type storageProver struct {
+	cctx    client.Context
+	appHash []byte
+}
+
+// newStorageProver returns a storageProver if the command asked for proofs.
+// It fixes the query height, by default to the latest with a following
+// header, so that every proof is against the same app hash.
+//
+// The header is verified either by a light client from a trusted header, or
+// by the signatures of the validator set that an explicitly trusted node
+// reports.  The queried node is never trusted by default.
+func newStorageProver(cmd *cobra.Command, cctx client.Context) (*storageProver, error) {
+	if prove, _ := cmd.Flags().GetBool(flagProve); !prove {
+		return nil, nil
+	}
+	trustedAddr, _ := cmd.Flags().GetString(flagTrustedNode)
+	trustHeight, _ := cmd.Flags().GetInt64(flagTrustHeight)
+	if trustedAddr == "" && trustHeight == 0 {
+		return nil, fmt.Errorf("--%s needs --%s, or --%s and --%s", flagProve, flagTrustedNode, flagTrustHeight, flagTrustHash)
+	}
+
+	node, err := cctx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+	status, err := node.Status(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	chainID := cctx.ChainID
+	if chainID == "" {
+		// The trusted header or validators pin the chain.
+		chainID = status.NodeInfo.Network
+	}
+	height := cctx.Height
+	if height == 0 {
+		height = status.SyncInfo.LatestBlockHeight - 1
+	}
+
+	// The app hash after a block is in the header of the next block.
+	nextHeight := height + 1
+	var block *tmtypes.LightBlock
+	if trustHeight != 0 {
+		block, err = verifyLightClientHeader(cmd, cctx, chainID, trustHeight, trustedAddr, nextHeight)
+	} else {
+		block, err = verifyTrustedNodeHeader(chainID, trustedAddr, nextHeight)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify header at height %d: %w", nextHeight, err)
+	}
+
+	return &storageProver{
+		cctx:    cctx.WithHeight(height),
+		appHash: block.Header.AppHash,
+	}, nil
+}
+
+// verifyTrustedNodeHeader returns the header at height from the trusted node,
+// once it checks that the node's validator set for the height signed it.
+func verifyTrustedNodeHeader(chainID, addr string, height int64) (*tmtypes.LightBlock, error) {
+	trusted, err := lighthttp.New(chainID, addr)
+	if err != nil {
+		return nil, err
+	}
+	// The provider checks that the commit is for the header, and that the
+	// validator set is the one the header names.
+	block, err := trusted.LightBlock(context.Background(), height)
+	if err != nil {
+		return nil, err
+	}
+	if err := block.ValidatorSet.VerifyCommitLight(chainID, block.Commit.BlockID, height, block.Commit); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// verifyLightClientHeader returns the header at height from the queried
+// node, verified by a light client from the trusted header.  The trusted
+// node, if any, is the light client's witness.
+func verifyLightClientHeader(
+	cmd *cobra.Command, cctx client.Context, chainID string, trustHeight int64, witnessAddr string, height int64,
+) (*tmtypes.LightBlock, error) {
+	trustHashHex, _ := cmd.Flags().GetString(flagTrustHash)
+	trustHash, err := hex.DecodeString(trustHashHex)
+	if err != nil {
+		return nil, fmt.Errorf("--%s is not hex: %w", flagTrustHash, err)
+	}
+	trustPeriod, _ := cmd.Flags().GetDuration(flagTrustPeriod)
+
+	var witnesses []string
+	var options []light.Option
+	if witnessAddr != "" {
+		witnesses = append(witnesses, witnessAddr)
+	} else {
+		// Skipping verification needs a witness to detect forks.
+		options = append(options, light.SequentialVerification())
+	}
+	lc, err := light.NewHTTPClient(
+		context.Background(),
+		chainID,
+		light.TrustOptions{Period: trustPeriod, Height: trustHeight, Hash: trustHash},
+		cctx.NodeURI,
+		witnesses,
+		lightdb.New(dbm.NewMemDB(), chainID),
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return lc.VerifyLightBlockAtHeight(context.Background(), height, time.Now())
+}
+
+// get returns the verified value of a SwingSet store key, or nil if the proof
+// shows it is absent.
+func (p *storageProver) get(key []byte) ([]byte, error) {
+	value, _, err := p.query(key)
+	return value, err
+}
+
+// query returns the verified value of a SwingSet store key, or nil if it is
+// absent, with the store's proof of it.
+func (p *storageProver) query(key []byte) ([]byte, *ics23.CommitmentProof, error) {
+	res, err := p.cctx.QueryABCI(abci.RequestQuery{
+		Path:  fmt.Sprintf("/store/%s/key", types.StoreKey),
+		Data:  key,
+		Prove: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.ProofOps == nil || len(res.ProofOps.Ops) == 0 {
+		return nil, nil, fmt.Errorf("node returned no proof for %q", key)
+	}
+
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(types.StoreKey), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+	prt := rootmulti.DefaultProofRuntime()
+	if len(res.Value) == 0 {
+		err = prt.VerifyAbsence(res.ProofOps, p.appHash, keyPath.String())
+	} else {
+		err = prt.VerifyValue(res.ProofOps, p.appHash, keyPath.String(), res.Value)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("proof of %q at height %d does not verify: %w", key, p.cctx.Height, err)
+	}
+
+	// The first operation proves the key within the SwingSet store.
+	op, err := storetypes.CommitmentOpDecoder(res.ProofOps.Ops[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	commitment, ok := op.(storetypes.CommitmentOp)
+	if !ok {
+		return nil, nil, fmt.Errorf("proof of %q is not a store commitment", key)
+	}
+	if len(res.Value) == 0 {
+		return nil, commitment.Proof, nil
+	}
+	return res.Value, commitment.Proof, nil
+}
+
+// keyFrom returns the first key in the SwingSet store at or after key, or
+// nil if there is none.  A verified proof that key is absent names the keys
+// beside it, with none between.
+func (p *storageProver) keyFrom(key []byte) ([]byte, error) {
+	value, proof, err := p.query(key)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		return key, nil
+	}
+	nonexist := ics23.Decompress(proof).GetNonexist()
+	if nonexist == nil {
+		return nil, fmt.Errorf("proof that %q is absent has no neighbours", key)
+	}
+	if nonexist.Right == nil {
+		return nil, nil
+	}
+	return nonexist.Right.Key, nil
+}
+
+// keyAfter returns the first key in the SwingSet store after key, or nil if
+// there is none.
+func (p *storageProver) keyAfter(key []byte) ([]byte, error) {
+	return p.keyFrom(append(append([]byte{}, key...), 0))
+}
+
+// getStorage returns the verified storage at path.
+func (p *storageProver) getStorage(path string) (*types.Storage, error) {
+	bz, err := p.get(types.DataKey(path))
+	if err != nil || bz == nil {
+		return types.NewStorage(), err
+	}
+
+	value, err := types.ParseStoreValue(bz)
+	if err != nil {
+		return nil, fmt.Errorf("storage %s: %w", path, err)
+	}
+	return &types.Storage{Value: string(value)}, nil
+}
+
+// checkKeys verifies that keys are consecutive children of path, in store
+// order.  If first, no child comes before them, and if last, none comes after
+// them, so that a whole listing is proven complete.
+func (p *storageProver) checkKeys(path string, keys []string, first, last bool) error {
+	childrenPrefix := types.ChildKeysPrefix(path)
+	var next []byte
+	var err error
+	if first {
+		if next, err = p.keyFrom(childrenPrefix); err != nil {
+			return err
+		}
+	}
+
+	for i, key := range keys {
+		childKey := types.ChildKeyPrefixed(types.JoinPath(path, key))
+		if i == 0 && !first {
+			// A later page starts at a child that need only exist.
+			bz, err := p.get(childKey)
+			if err != nil {
+				return err
+			}
+			if bz == nil {
+				return fmt.Errorf("storage %s has no child %s", path, key)
+			}
+		} else if !bytes.Equal(next, childKey) {
+			if next != nil && bytes.HasPrefix(next, childrenPrefix) && bytes.Compare(next, childKey) < 0 {
+				return fmt.Errorf("storage %s has a child before %s that was not listed", path, key)
+			}
+			return fmt.Errorf("storage %s has no child %s", path, key)
+		}
+		if next, err = p.keyAfter(childKey); err != nil {
+			return err
+		}
+	}
+
+	if last && (first || len(keys) > 0) && next != nil && bytes.HasPrefix(next, childrenPrefix) {
+		return fmt.Errorf("storage %s has children after those listed", path)
+	}
+	return nil
+}
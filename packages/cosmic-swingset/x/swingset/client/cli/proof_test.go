@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// storeClient answers ABCI store queries from a multistore.
+type storeClient struct {
+	rpcclient.Client
+	cms *rootmulti.Store
+}
+
+func (c storeClient) ABCIQueryWithOptions(
+	_ context.Context, path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	res := c.cms.Query(abci.RequestQuery{
+		Path:   strings.TrimPrefix(path, "/store"),
+		Data:   data,
+		Height: opts.Height,
+		Prove:  opts.Prove,
+	})
+	return &ctypes.ResultABCIQuery{Response: res}, nil
+}
+
+// newTestProver returns a storageProver of a committed SwingSet store with a
+// child key entry for each of paths.
+func newTestProver(t *testing.T, paths ...string) *storageProver {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	cms := rootmulti.NewStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	if err := cms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+	store := cms.GetKVStore(key)
+	for _, path := range paths {
+		store.Set(types.ChildKeyPrefixed(path), types.ChildKeyValue)
+		store.Set(types.DataKey(path), types.StoreValue([]byte(path)))
+	}
+	commit := cms.Commit()
+
+	return &storageProver{
+		cctx:    client.Context{}.WithClient(storeClient{cms: cms}).WithHeight(commit.Version),
+		appHash: commit.Hash,
+	}
+}
+
+func TestCheckKeys(t *testing.T) {
+	p := newTestProver(t, "a", "a.x", "b", "c")
+	for _, tc := range []struct {
+		path        string
+		keys        []string
+		first, last bool
+		ok          bool
+	}{
+		{"", []string{"a", "b", "c"}, true, true, true},
+		{"", []string{"a", "c"}, true, true, false},
+		{"", []string{"b", "c"}, true, true, false},
+		{"", []string{"a", "b"}, true, true, false},
+		{"", []string{"a", "b"}, true, false, true},
+		{"", []string{"b", "c"}, false, true, true},
+		{"", []string{"a", "b", "c", "d"}, true, true, false},
+		{"a", []string{"x"}, true, true, true},
+		{"a", nil, true, true, false},
+		{"b", nil, true, true, true},
+	} {
+		err := p.checkKeys(tc.path, tc.keys, tc.first, tc.last)
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("checkKeys(%q, %q, %v, %v) gave %v", tc.path, tc.keys, tc.first, tc.last, err)
+		}
+	}
+}
+
+func TestGetStorageProvesAbsence(t *testing.T) {
+	p := newTestProver(t, "a")
+	storage, err := p.getStorage("a")
+	if err != nil || storage.Value != "a" {
+		t.Errorf("storage a is %v, %v", storage, err)
+	}
+	if storage, err = p.getStorage("b"); err != nil || storage.Value != "" {
+		t.Errorf("storage b is %v, %v", storage, err)
+	}
+
+	p.appHash = make([]byte, len(p.appHash))
+	if _, err := p.getStorage("a"); err == nil {
+		t.Error("verified storage against the wrong app hash")
+	}
+}
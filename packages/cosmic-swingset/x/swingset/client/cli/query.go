@@ -2,12 +2,15 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/cli"
+	"google.golang.org/grpc"
 )
 
 func GetQueryCmd(storeKey string) *cobra.Command {
@@ -22,7 +25,12 @@ func GetQueryCmd(storeKey string) *cobra.Command {
 		GetCmdGetEgress(storeKey),
 		GetCmdGetStorage(storeKey),
 		GetCmdGetKeys(storeKey),
+		GetCmdGetEntries(storeKey),
+		GetCmdStorageDiff(storeKey),
+		GetCmdUsage(storeKey),
+		GetCmdParams(storeKey),
 		GetCmdMailbox(storeKey),
+		GetCmdWatch(storeKey),
 	)
 
 	return swingsetQueryCmd
@@ -34,7 +42,10 @@ func GetCmdGetEgress(queryRoute string) *cobra.Command {
 		Short: "get egress info for account",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cctx := client.GetClientContextFromCmd(cmd)
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
 			bech32 := args[0]
 
 			res, _, err := cctx.QueryWithData(fmt.Sprintf("custom/%s/egress/%s", queryRoute, bech32), nil)
@@ -61,9 +72,24 @@ func GetCmdGetStorage(queryRoute string) *cobra.Command {
 		Short: "get storage for path",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cctx := client.GetClientContextFromCmd(cmd)
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
 			path := args[0]
 
+			prover, err := newStorageProver(cmd, cctx)
+			if err != nil {
+				return err
+			}
+			if prover != nil {
+				storage, err := prover.getStorage(path)
+				if err != nil {
+					return err
+				}
+				return cctx.PrintOutputLegacy(storage)
+			}
+
 			res, _, err := cctx.QueryWithData(fmt.Sprintf("custom/%s/storage/%s", queryRoute, path), nil)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "could not find storage path - %s: %s\n", path, err)
@@ -77,6 +103,7 @@ func GetCmdGetStorage(queryRoute string) *cobra.Command {
 	}
 
 	flags.AddQueryFlagsToCmd(cmd)
+	addProofFlags(cmd)
 	return cmd
 }
 
@@ -87,28 +114,93 @@ func GetCmdGetKeys(queryRoute string) *cobra.Command {
 		Short: "get storage subkeys for path",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cctx := client.GetClientContextFromCmd(cmd)
-			var path string
-			if len(args) > 0 {
-				path = args[0]
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
 			}
 
-			res, _, err := cctx.QueryWithData(fmt.Sprintf("custom/%s/keys/%s", queryRoute, path), nil)
+			prover, err := newStorageProver(cmd, cctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "could not find keys path - %s: %s\n", path, err)
-				return nil
+				return err
+			}
+			if prover != nil {
+				cctx = prover.cctx
 			}
 
-			var out types.Keys
-			cctx.JSONMarshaler.MustUnmarshalJSON(res, &out)
-			return cctx.PrintOutputLegacy(&out)
+			queryClient := types.NewQueryClient(cctx)
+			path := storagePathArg(args)
+			res, err := queryClient.Keys(cmd.Context(), &types.QueryStorageKeysRequest{
+				Path:       path,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			if prover != nil {
+				// A listing from its start with no next page is proven complete.
+				first := pageReq.Key == nil && pageReq.Offset == 0
+				last := res.Pagination == nil || res.Pagination.NextKey == nil
+				if err := prover.checkKeys(types.PathString(path), res.Keys, first, last); err != nil {
+					return err
+				}
+			}
+
+			return cctx.PrintOutput(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "keys")
+	addProofFlags(cmd)
+	return cmd
+}
+
+// GetCmdGetEntries queries storage keys with their values
+func GetCmdGetEntries(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "entries [path]",
+		Short: "get storage subkeys and their values for path",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(cctx)
+			res, err := queryClient.Entries(cmd.Context(), &types.QueryStorageEntriesRequest{
+				Path:       storagePathArg(args),
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return cctx.PrintOutput(res)
 		},
 	}
 
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "entries")
 	return cmd
 }
 
+// storagePathArg returns the segments of the optional storage path argument.
+func storagePathArg(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	return types.PathSegments(args[0])
+}
+
 // GetCmdMailbox queries information about a mailbox
 func GetCmdMailbox(queryRoute string) *cobra.Command {
 	cmd := &cobra.Command{
@@ -116,9 +208,24 @@ func GetCmdMailbox(queryRoute string) *cobra.Command {
 		Short: "get mailbox for peer",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cctx := client.GetClientContextFromCmd(cmd)
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
 			peer := args[0]
 
+			prover, err := newStorageProver(cmd, cctx)
+			if err != nil {
+				return err
+			}
+			if prover != nil {
+				storage, err := prover.getStorage("mailbox." + peer)
+				if err != nil {
+					return err
+				}
+				return cctx.PrintOutputLegacy(storage)
+			}
+
 			res, _, err := cctx.QueryWithData(fmt.Sprintf("custom/%s/mailbox/%s", queryRoute, peer), nil)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "could not find peer mailbox - %s: %s\n", peer, err)
@@ -132,5 +239,107 @@ func GetCmdMailbox(queryRoute string) *cobra.Command {
 	}
 
 	flags.AddQueryFlagsToCmd(cmd)
+	addProofFlags(cmd)
+	return cmd
+}
+
+// GetCmdUsage queries the storage used under a path
+func GetCmdUsage(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage [path]",
+		Short: "get the storage bytes and children at and below path",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(cctx)
+			res, err := queryClient.Usage(cmd.Context(), &types.QueryStorageUsageRequest{
+				Path: storagePathArg(args),
+			})
+			if err != nil {
+				return err
+			}
+
+			return cctx.PrintOutput(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdParams queries the module parameters
+func GetCmdParams(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "get the swingset module parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := client.ReadQueryCommandFlags(client.GetClientContextFromCmd(cmd), cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(cctx)
+			res, err := queryClient.Params(cmd.Context(), &types.QueryParamsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return cctx.PrintOutput(&res.Params)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+const flagGRPCAddr = "grpc-addr"
+
+// GetCmdWatch streams committed storage changes
+func GetCmdWatch(queryRoute string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [path]",
+		Short: "print committed storage changes at or below path",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx := client.GetClientContextFromCmd(cmd)
+			addr, _ := cmd.Flags().GetString(flagGRPCAddr)
+
+			// Streams are not carried by ABCI queries, so talk to the node's
+			// gRPC server.
+			conn, err := grpc.Dial(addr, grpc.WithInsecure())
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			watchClient := types.NewWatchClient(conn)
+			stream, err := watchClient.Storage(cmd.Context(), &types.WatchStorageRequest{
+				Path: storagePathArg(args),
+			})
+			if err != nil {
+				return err
+			}
+			for {
+				change, err := stream.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if err := cctx.PrintOutput(change); err != nil {
+					return err
+				}
+			}
+		},
+	}
+
+	cmd.Flags().String(flagGRPCAddr, "localhost:9090", "The node's gRPC server address")
+	cmd.Flags().StringP(cli.OutputFlag, "o", "text", "Output format (text|json)")
 	return cmd
 }
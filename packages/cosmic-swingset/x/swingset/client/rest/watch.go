@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// StorageSubscriber subscribes to committed storage changes at or below a
+// path.
+type StorageSubscriber interface {
+	Subscribe(path string) (<-chan types.StorageChange, func())
+}
+
+// RegisterWatchRoutes defines the websocket routes that stream storage
+// changes.  They are only available in the node process, since they are fed by
+// the running app.  Browsers may connect from the node's own origin, or from
+// one of allowedOrigins, where "*" allows any.
+func RegisterWatchRoutes(r *mux.Router, storeName string, subscriber StorageSubscriber, allowedOrigins []string) {
+	upgrader := &websocket.Upgrader{CheckOrigin: checkOrigin(allowedOrigins)}
+	r.HandleFunc(fmt.Sprintf("/%s/watch/{%s}", storeName, pathName), watchStorageHandler(upgrader, subscriber)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/watch", storeName), watchStorageHandler(upgrader, subscriber)).Methods("GET")
+}
+
+// checkOrigin returns a websocket origin check that allows requests without
+// an Origin, which do not come from browsers, and those from the same host
+// or from one of allowedOrigins.
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowed["*"] || allowed[strings.ToLower(origin)] {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+func watchStorageHandler(upgrader *websocket.Upgrader, subscriber StorageSubscriber) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path, ok := mux.Vars(r)[pathName]
+		if ok {
+			if err := types.ValidatePath(path); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid path %q: %v", path, err))
+				return
+			}
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		defer conn.Close()
+
+		changes, cancel := subscriber.Subscribe(path)
+		defer cancel()
+
+		// Notice when the client goes away.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case change, ok := <-changes:
+				if !ok {
+					conn.WriteMessage(websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "storage watcher fell behind"))
+					return
+				}
+				if err := conn.WriteJSON(&change); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
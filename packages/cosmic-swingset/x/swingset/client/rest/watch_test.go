@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	for _, tc := range []struct {
+		allowed []string
+		origin  string
+		ok      bool
+	}{
+		{nil, "", true},
+		{nil, "http://node.example:1317", true},
+		{nil, "https://NODE.example:1317", true},
+		{nil, "https://wallet.example", false},
+		{nil, "null", false},
+		{[]string{"https://wallet.example/"}, "https://wallet.example", true},
+		{[]string{"https://wallet.example"}, "http://wallet.example", false},
+		{[]string{"*"}, "https://anything.example", true},
+	} {
+		r := httptest.NewRequest("GET", "http://node.example:1317/swingset/watch", nil)
+		if tc.origin != "" {
+			r.Header.Set("Origin", tc.origin)
+		}
+		if ok := checkOrigin(tc.allowed)(r); ok != tc.ok {
+			t.Errorf("origin %q with %q allowed gave %v", tc.origin, tc.allowed, ok)
+		}
+	}
+}
+
+// subscriberFunc is a StorageSubscriber that records the subscribed paths.
+type subscriberFunc func(path string)
+
+func (f subscriberFunc) Subscribe(path string) (<-chan types.StorageChange, func()) {
+	f(path)
+	changes := make(chan types.StorageChange)
+	return changes, func() {}
+}
+
+func TestWatchInvalidPath(t *testing.T) {
+	var subscribed []string
+	r := mux.NewRouter()
+	RegisterWatchRoutes(r, "swingset", subscriberFunc(func(path string) {
+		subscribed = append(subscribed, path)
+	}), nil)
+
+	for _, path := range []string{"a..b", "a.", "%2Ea", "a%5C"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "http://node.example:1317/swingset/watch/"+path, nil))
+		// A valid path would fail the websocket upgrade instead.
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid path") {
+			t.Errorf("watching %q gave status %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+	if len(subscribed) != 0 {
+		t.Errorf("subscribed to %q", subscribed)
+	}
+}
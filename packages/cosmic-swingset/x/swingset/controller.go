@@ -1,8 +1,11 @@
 package swingset
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -12,56 +15,284 @@ type ControllerContext struct {
 	Context               sdk.Context
 	StoragePort           int
 	IBCChannelHandlerPort int
+	// GasMeter is charged for storage downcalls.
+	GasMeter sdk.GasMeter
+	// EmptyStorageValues is whether the controller negotiated
+	// CapabilityEmptyStorageValues.
+	EmptyStorageValues bool
 }
 
-var controllerContext ControllerContext
-
 type PortHandler interface {
 	Receive(*ControllerContext, string) (string, error)
 }
 
-var portToHandler = make(map[int]PortHandler)
-var portToName = make(map[int]string)
-var nameToPort = make(map[string]int)
-var lastPort = 0
+// Controller holds the state that one app shares with its SwingSet
+// controller: the registered ports and the contexts of the upcalls in
+// progress.  Each app owns its own Controller, so that several apps can run in
+// one process.
+type Controller struct {
+	// callMu is held by the outermost upcall in progress, so that upcalls
+	// from different goroutines take turns.
+	callMu sync.Mutex
+
+	mu sync.Mutex
+	// stack has a frame for each upcall that is waiting for its reply, the
+	// innermost last.
+	stack []*ControllerContext
+
+	portToHandler map[int]PortHandler
+	portToName    map[int]string
+	nameToPort    map[string]int
+	lastPort      int
+
+	committedHeight  int64
+	endBlockHeight   int64
+	endBlockTime     int64
+	downcallsInBlock int
+	// blockGasMeter is charged for storage downcalls during BeginBlock and
+	// EndBlock.  It is guarded by mu, since queries read it.
+	blockGasMeter sdk.GasMeter
+
+	// transcript and validate are guarded by mu, since they may be set while
+	// downcalls arrive.
+	transcript *Recorder
+
+	// handshake is the controller's reply to AG_COSMOS_INIT.
+	handshake *Handshake
+
+	// validate checks all messages against the schema registry.
+	validate bool
+}
+
+// NewController creates a Controller with the "storage" port registered.
+func NewController() *Controller {
+	c := &Controller{
+		portToHandler: make(map[int]PortHandler),
+		portToName:    make(map[int]string),
+		nameToPort:    make(map[string]int),
+	}
+	c.RegisterPortHandler("storage", NewStorageHandler())
+	return c
+}
+
+// PushContext makes ctx and keeper the context for downcalls until the
+// returned function is called.  Upcalls may nest, in which case downcalls
+// run against the innermost one.
+func (c *Controller) PushContext(ctx sdk.Context, keeper *Keeper) func() {
+	frame := &ControllerContext{
+		Keeper:                keeper,
+		Context:               ctx,
+		StoragePort:           c.GetPort("storage"),
+		IBCChannelHandlerPort: c.GetPort("dibc"),
+		GasMeter:              storageGasMeter(ctx),
+		EmptyStorageValues:    c.Supports(CapabilityEmptyStorageValues),
+	}
+	if ctx.MultiStore() != nil {
+		frame.Context = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	}
+	if ctx.Context() != nil {
+		// Mark upcalls made while handling downcalls as nested.
+		frame.Context = frame.Context.WithValue(nestedCallKey{}, c)
+	}
 
-func SetControllerContext(ctx sdk.Context) func() {
-	controllerContext.Context = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	c.mu.Lock()
+	c.stack = append(c.stack, frame)
+	c.mu.Unlock()
 	return func() {
-		controllerContext.Context = sdk.Context{}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// Usually frame is on top, but be robust to out-of-order returns.
+		for i := len(c.stack) - 1; i >= 0; i-- {
+			if c.stack[i] == frame {
+				c.stack = append(c.stack[:i], c.stack[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Call sends an upcall to the controller through send, making ctx and keeper
+// the context for any downcalls it causes, and records its telemetry under
+// actionType, which is "TYPE" or "IBC_EVENT/event".
+func (c *Controller) Call(
+	ctx sdk.Context, keeper *Keeper, actionType, str string,
+	send func(context.Context, bool, string) (string, error),
+) (string, error) {
+	if err := c.CheckUpcall(str); err != nil {
+		return "", err
+	}
+	// DeliverTx, queries and simulations upcall from their own goroutines,
+	// but downcalls run against the innermost upcall, so upcalls take turns.
+	if !c.isNested(ctx) {
+		c.callMu.Lock()
+		defer c.callMu.Unlock()
+	}
+	defer c.PushContext(ctx, keeper)()
+
+	goCtx := ctx.Context()
+	if goCtx == nil {
+		goCtx = context.Background()
 	}
+	start := time.Now()
+	reply, err := send(goCtx, true, str)
+
+	// A transaction that ran out of gas fails, but the block budget only
+	// fails the downcalls that exceed it, since the block must go on.
+	if meter := storageGasMeter(ctx); err == nil && meter != c.getBlockGasMeter() {
+		err = checkGas(meter)
+	}
+
+	countUpcall(actionType, start, str, reply, err)
+	return reply, err
 }
 
-func SetControllerKeeper(keeper *Keeper) func() {
-	controllerContext.Keeper = keeper
+// nestedCallKey is the Context value that marks a downcall's Context with
+// its Controller.
+type nestedCallKey struct{}
+
+// isNested returns whether an upcall with ctx was made while handling a
+// downcall, and so is nested within the upcall that holds callMu.
+func (c *Controller) isNested(ctx sdk.Context) bool {
+	return ctx.Context() != nil && ctx.Value(nestedCallKey{}) == c
+}
+
+// setBlockGasMeter starts a block's budget for storage downcalls.
+func (c *Controller) setBlockGasMeter(meter sdk.GasMeter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blockGasMeter = meter
+}
+
+// getBlockGasMeter returns the current block's budget for storage downcalls.
+func (c *Controller) getBlockGasMeter() sdk.GasMeter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.blockGasMeter
+}
+
+// Depth returns the number of upcalls in progress.
+func (c *Controller) Depth() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.stack)
+}
+
+// activeContext returns the frame of the innermost upcall in progress.
+func (c *Controller) activeContext() (*ControllerContext, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.stack) == 0 {
+		return nil, errors.New("no upcall to the controller is in progress")
+	}
+	frame := c.stack[len(c.stack)-1]
+	if frame.Context.MultiStore() == nil {
+		return nil, errors.New("the upcall in progress has no state to access")
+	}
+	return frame, nil
+}
+
+// SetTranscriptRecorder starts recording downcalls to r.
+func (c *Controller) SetTranscriptRecorder(r *Recorder) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transcript = r
 	return func() {
-		controllerContext.Keeper = nil
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.transcript = nil
 	}
 }
 
-func GetPort(name string) int {
-	return nameToPort[name]
+func (c *Controller) getTranscriptRecorder() *Recorder {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transcript
+}
+
+// SetValidation turns checking of every upcall and downcall against the
+// schema registry on or off.  It is meant for debugging, since it is slow.
+func (c *Controller) SetValidation(validate bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validate = validate
 }
 
-func RegisterPortHandler(name string, portHandler PortHandler) int {
-	lastPort++
-	portToHandler[lastPort] = portHandler
-	portToName[lastPort] = name
-	nameToPort[name] = lastPort
-	return lastPort
+func (c *Controller) validating() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.validate
 }
-func UnregisterPortHandler(portNum int) error {
-	delete(portToHandler, portNum)
-	name := portToName[portNum]
-	delete(portToName, portNum)
-	delete(nameToPort, name)
+
+// CheckUpcall validates an outgoing action if validation is on.
+func (c *Controller) CheckUpcall(action string) error {
+	if !c.validating() || c.Encoding() != EncodingJSON {
+		return nil
+	}
+	if err := ValidateUpcall(action); err != nil {
+		return fmt.Errorf("invalid upcall: %w", err)
+	}
 	return nil
 }
 
-func ReceiveFromController(portNum int, msg string) (string, error) {
-	handler := portToHandler[portNum]
+func (c *Controller) GetPort(name string) int {
+	return c.nameToPort[name]
+}
+
+func (c *Controller) RegisterPortHandler(name string, portHandler PortHandler) int {
+	c.lastPort++
+	c.portToHandler[c.lastPort] = portHandler
+	c.portToName[c.lastPort] = name
+	c.nameToPort[name] = c.lastPort
+	return c.lastPort
+}
+
+func (c *Controller) UnregisterPortHandler(portNum int) error {
+	delete(c.portToHandler, portNum)
+	name := c.portToName[portNum]
+	delete(c.portToName, portNum)
+	delete(c.nameToPort, name)
+	return nil
+}
+
+func (c *Controller) ReceiveFromController(portNum int, msg string) (ret string, err error) {
+	defer func() {
+		c.countDowncall(c.portToName[portNum], msg, ret)
+	}()
+	if transcript := c.getTranscriptRecorder(); transcript != nil {
+		var height int64
+		if frame, err := c.activeContext(); err == nil {
+			height = frame.Context.BlockHeight()
+		}
+		transcript.record(TranscriptEntry{Height: height, Dir: TranscriptDowncall, Port: portNum, Body: msg}, nil)
+		ret, err := c.receiveFromController(portNum, msg)
+		transcript.record(TranscriptEntry{Height: height, Dir: TranscriptDowncallReply, Port: portNum, Body: ret}, err)
+		return ret, err
+	}
+	return c.receiveFromController(portNum, msg)
+}
+
+func (c *Controller) receiveFromController(portNum int, msg string) (string, error) {
+	handler := c.portToHandler[portNum]
 	if handler == nil {
 		return "", errors.New(fmt.Sprintf("Unregistered port %d", portNum))
 	}
-	return handler.Receive(&controllerContext, msg)
+	frame, err := c.activeContext()
+	if err != nil {
+		return "", fmt.Errorf("cannot receive on port %d: %w", portNum, err)
+	}
+	if c.Encoding() == EncodingProtobuf {
+		if ph, ok := handler.(protoPortHandler); ok {
+			bz, err := decodePortMessage(msg)
+			if err != nil {
+				return "", err
+			}
+			return ph.ReceiveProto(frame, bz)
+		}
+	}
+	if c.validating() {
+		if err := ValidateDowncall(c.portToName[portNum], msg); err != nil {
+			return "", fmt.Errorf("invalid downcall: %w", err)
+		}
+	}
+	return handler.Receive(frame, msg)
 }
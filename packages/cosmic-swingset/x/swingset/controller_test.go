@@ -0,0 +1,158 @@
+package swingset
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// heightHandler replies to each downcall with the height of its Context,
+// after calling nested if set.
+type heightHandler struct {
+	nested func(ctx sdk.Context) error
+}
+
+func (h heightHandler) Receive(ctx *ControllerContext, str string) (string, error) {
+	if h.nested != nil {
+		if err := h.nested(ctx.Context); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprint(ctx.Context.BlockHeight()), nil
+}
+
+func newTestContext() sdk.Context {
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	return sdk.NewContext(cms, tmproto.Header{}, false, log.NewNopLogger())
+}
+
+func TestConcurrentUpcalls(t *testing.T) {
+	controller := NewController()
+	port := controller.RegisterPortHandler("height", heightHandler{})
+	send := func(context.Context, bool, string) (string, error) {
+		// Give the other upcalls a chance to start.
+		time.Sleep(time.Millisecond)
+		return controller.ReceiveFromController(port, "{}")
+	}
+
+	ctx := newTestContext()
+	var keeper Keeper
+	var wg sync.WaitGroup
+	for height := int64(1); height <= 20; height++ {
+		wg.Add(1)
+		go func(height int64) {
+			defer wg.Done()
+			reply, err := controller.Call(ctx.WithBlockHeight(height), &keeper, "TEST", `{"type":"TEST"}`, send)
+			if err != nil {
+				t.Error(err)
+			} else if reply != fmt.Sprint(height) {
+				t.Errorf("downcall of the upcall at height %d ran at height %s", height, reply)
+			}
+		}(height)
+	}
+	wg.Wait()
+	if depth := controller.Depth(); depth != 0 {
+		t.Errorf("%d upcalls left in progress", depth)
+	}
+}
+
+func TestNestedUpcall(t *testing.T) {
+	controller := NewController()
+	var keeper Keeper
+	nestedSend := func(context.Context, bool, string) (string, error) {
+		return "true", nil
+	}
+	port := controller.RegisterPortHandler("height", heightHandler{
+		nested: func(ctx sdk.Context) error {
+			_, err := controller.Call(ctx, &keeper, "NESTED", `{"type":"NESTED"}`, nestedSend)
+			return err
+		},
+	})
+	send := func(context.Context, bool, string) (string, error) {
+		return controller.ReceiveFromController(port, "{}")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := controller.Call(newTestContext().WithBlockHeight(3), &keeper, "TEST", `{"type":"TEST"}`, send)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("nested upcall deadlocked")
+	}
+}
+
+// TestBlockGasMeterDuringQueries is meant for -race: a new block's gas meter
+// is set while queries upcall from their own goroutines.
+func TestBlockGasMeterDuringQueries(t *testing.T) {
+	controller := NewController()
+	var keeper Keeper
+	send := func(context.Context, bool, string) (string, error) {
+		return "true", nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := controller.Call(newTestContext(), &keeper, "QUERY", `{"type":"QUERY"}`, send); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		controller.setBlockGasMeter(sdk.NewGasMeter(1000))
+	}
+	wg.Wait()
+}
+
+// TestSetTranscriptRecorderDuringDowncalls is meant for -race: recording and
+// validation are switched while downcalls arrive.
+func TestSetTranscriptRecorderDuringDowncalls(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transcript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	recorder, err := NewRecorder(filepath.Join(dir, "transcript.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Close()
+
+	controller := NewController()
+	port := controller.RegisterPortHandler("height", heightHandler{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			controller.ReceiveFromController(port, "{}")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		stop := controller.SetTranscriptRecorder(recorder)
+		controller.SetValidation(i%2 == 0)
+		stop()
+	}
+	wg.Wait()
+}
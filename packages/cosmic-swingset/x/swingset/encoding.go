@@ -0,0 +1,276 @@
+package swingset
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// Encodings of controller traffic, negotiated at AG_COSMOS_INIT.
+const (
+	// EncodingJSON sends actions and port messages as JSON text.
+	EncodingJSON = "json"
+	// EncodingProtobuf sends them as base64 of the messages defined in
+	// proto/agoric/swingset/controller.proto.
+	EncodingProtobuf = "protobuf"
+)
+
+// ControllerEncodings are the encodings this node offers, in order of
+// preference.
+var ControllerEncodings = []string{EncodingProtobuf, EncodingJSON}
+
+// protoAction is an upcall action that has a binary encoding.
+type protoAction interface {
+	toProto() *types.ControllerAction
+}
+
+// protoPortHandler is a PortHandler that accepts binary-encoded messages.
+type protoPortHandler interface {
+	ReceiveProto(*ControllerContext, []byte) (string, error)
+}
+
+// Encoding returns the negotiated encoding of controller traffic.
+func (c *Controller) Encoding() string {
+	hs := c.Handshake()
+	if hs == nil || hs.Encoding == "" {
+		return EncodingJSON
+	}
+	return hs.Encoding
+}
+
+// EncodeAction serializes an upcall action in the negotiated encoding.
+func (c *Controller) EncodeAction(action interface{}) (string, error) {
+	return EncodeAction(c.Encoding(), action)
+}
+
+// EncodeAction serializes an upcall action in the given encoding.
+func EncodeAction(encoding string, action interface{}) (string, error) {
+	switch encoding {
+	case EncodingJSON:
+		bz, err := json.Marshal(action)
+		return string(bz), err
+
+	case EncodingProtobuf:
+		pa, ok := action.(protoAction)
+		if !ok {
+			return "", fmt.Errorf("%T has no %s encoding", action, encoding)
+		}
+		bz, err := pa.toProto().Marshal()
+		if err != nil {
+			return "", err
+		}
+		return encodePortMessage(bz), nil
+	}
+	return "", fmt.Errorf("unknown controller encoding %q", encoding)
+}
+
+func (action *beginBlockAction) toProto() *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+		Body: &types.ControllerAction_BeginBlock{BeginBlock: &types.BeginBlockAction{
+			StoragePort: int32(action.StoragePort),
+			ChainID:     action.ChainID,
+		}},
+	}
+}
+
+func (action *endBlockAction) toProto() *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+		Body: &types.ControllerAction_EndBlock{EndBlock: &types.EndBlockAction{
+			StoragePort: int32(action.StoragePort),
+		}},
+	}
+}
+
+func (action *commitBlockAction) toProto() *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+	}
+}
+
+func (action *deliverInboundAction) toProto() *types.ControllerAction {
+	messages := make([]types.InboundMessage, len(action.Messages))
+	for i, m := range action.Messages {
+		messages[i] = types.InboundMessage{Num: m.Num, Body: m.Body}
+	}
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+		Body: &types.ControllerAction_DeliverInbound{DeliverInbound: &types.DeliverInboundAction{
+			Peer:        action.Peer,
+			Messages:    messages,
+			Ack:         action.Ack,
+			StoragePort: int32(action.StoragePort),
+		}},
+	}
+}
+
+func (action *provisionAction) toProto() *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+		Body: &types.ControllerAction_Provision{Provision: &types.ProvisionAction{
+			Nickname:   action.Nickname,
+			Address:    action.Address,
+			PowerFlags: action.PowerFlags,
+			Submitter:  action.Submitter,
+		}},
+	}
+}
+
+// ibcEventToProto wraps the body of an IBC_EVENT.
+func ibcEventToProto(blockHeight, blockTime int64, event *types.IBCEvent) *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        "IBC_EVENT",
+		BlockHeight: blockHeight,
+		BlockTime:   blockTime,
+		Body:        &types.ControllerAction_IbcEvent{IbcEvent: event},
+	}
+}
+
+func (action *sendPacketAction) toProto() *types.ControllerAction {
+	return ibcEventToProto(action.BlockHeight, action.BlockTime, &types.IBCEvent{
+		Event:     action.Event,
+		Packet:    action.Packet,
+		Submitter: action.Sender,
+	})
+}
+
+func (ev *channelOpenInitEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:          ev.Event,
+		Order:          ev.Order,
+		ConnectionHops: ev.ConnectionHops,
+		PortID:         ev.PortID,
+		ChannelID:      ev.ChannelID,
+		Counterparty:   ev.Counterparty,
+		Version:        ev.Version,
+	})
+}
+
+func (ev *channelOpenTryEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:               ev.Event,
+		Order:               ev.Order,
+		ConnectionHops:      ev.ConnectionHops,
+		PortID:              ev.PortID,
+		ChannelID:           ev.ChannelID,
+		Counterparty:        ev.Counterparty,
+		Version:             ev.Version,
+		CounterpartyVersion: ev.CounterpartyVersion,
+	})
+}
+
+func (ev *channelOpenAckEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:               ev.Event,
+		PortID:              ev.PortID,
+		ChannelID:           ev.ChannelID,
+		CounterpartyVersion: ev.CounterpartyVersion,
+	})
+}
+
+func (ev *channelOpenConfirmEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:     ev.Event,
+		PortID:    ev.PortID,
+		ChannelID: ev.ChannelID,
+	})
+}
+
+func (ev *channelCloseInitEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:     ev.Event,
+		PortID:    ev.PortID,
+		ChannelID: ev.ChannelID,
+	})
+}
+
+func (ev *channelCloseConfirmEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:     ev.Event,
+		PortID:    ev.PortID,
+		ChannelID: ev.ChannelID,
+	})
+}
+
+func (ev *receivePacketEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:  ev.Event,
+		Packet: ev.Packet,
+	})
+}
+
+func (ev *acknowledgementPacketEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:           ev.Event,
+		Packet:          ev.Packet,
+		Acknowledgement: ev.Acknowledgement,
+	})
+}
+
+func (ev *timeoutPacketEvent) toProto() *types.ControllerAction {
+	return ibcEventToProto(ev.BlockHeight, ev.BlockTime, &types.IBCEvent{
+		Event:  ev.Event,
+		Packet: ev.Packet,
+	})
+}
+
+func storageMessageFromProto(pm *types.StorageMessage) *storageMessage {
+	var entries [][]string
+	for _, entry := range pm.Entries {
+		entries = append(entries, []string{entry.Key, entry.Value})
+	}
+	var expected *string
+	if pm.Expected != nil {
+		expected = &pm.Expected.Value
+	}
+	return &storageMessage{
+		Method:   pm.Method,
+		Key:      pm.Key,
+		Value:    pm.Value,
+		Entries:  entries,
+		Keys:     pm.Keys,
+		Expected: expected,
+		After:    pm.After,
+		Limit:    pm.Limit,
+	}
+}
+
+func channelMessageFromProto(pm *types.ChannelMessage) *channelMessage {
+	return &channelMessage{
+		Type:            pm.Type,
+		Method:          pm.Method,
+		Packet:          pm.Packet,
+		RelativeTimeout: pm.RelativeTimeout,
+		Order:           pm.Order,
+		Hops:            pm.Hops,
+		Version:         pm.Version,
+		Ack:             pm.Ack,
+	}
+}
+
+// encodePortMessage wraps a binary-encoded downcall for a string transport.
+func encodePortMessage(bz []byte) string {
+	return base64.StdEncoding.EncodeToString(bz)
+}
+
+// decodePortMessage unwraps a binary-encoded downcall.
+func decodePortMessage(msg string) ([]byte, error) {
+	bz, err := base64.StdEncoding.DecodeString(msg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s port message: %w", EncodingProtobuf, err)
+	}
+	return bz, nil
+}
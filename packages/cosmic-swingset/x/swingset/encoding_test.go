@@ -0,0 +1,454 @@
+package swingset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// The shape of the benchmarked controller traffic: a DELIVER_INBOUND of
+// benchMessages mailbox messages of benchMessageBytes each, and IBC packets
+// of benchPacketBytes.
+const (
+	benchMessages     = 1000
+	benchMessageBytes = 256
+	benchPacketBytes  = 64 * 1024
+)
+
+func benchPacket() channeltypes.Packet {
+	return channeltypes.NewPacket(
+		bytes.Repeat([]byte{0xa5}, benchPacketBytes), 1,
+		"port-0", "channel-0", "port-1", "channel-1",
+		clienttypes.NewHeight(0, 1000), 0,
+	)
+}
+
+// BenchmarkEncodeAction compares the controller encodings of a
+// DELIVER_INBOUND and of a receivePacket upcall.
+func BenchmarkEncodeAction(b *testing.B) {
+	mailbox := make([]inboundMessage, benchMessages)
+	body := string(bytes.Repeat([]byte("m"), benchMessageBytes))
+	for i := range mailbox {
+		mailbox[i] = inboundMessage{Num: uint64(i + 1), Body: body}
+	}
+
+	upcalls := []struct {
+		name   string
+		action interface{}
+	}{
+		{"DELIVER_INBOUND", &deliverInboundAction{
+			Type:        "DELIVER_INBOUND",
+			Peer:        "agoric1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+			Messages:    mailbox,
+			Ack:         benchMessages,
+			StoragePort: 1,
+			BlockHeight: 100,
+			BlockTime:   1600000000,
+		}},
+		{"IBC_EVENT/receivePacket", &receivePacketEvent{
+			Type:        "IBC_EVENT",
+			Event:       "receivePacket",
+			Packet:      benchPacket(),
+			BlockHeight: 100,
+			BlockTime:   1600000000,
+		}},
+	}
+
+	for _, up := range upcalls {
+		for _, encoding := range []string{EncodingJSON, EncodingProtobuf} {
+			action, encoding := up.action, encoding
+			b.Run(up.name+"/"+encoding, func(b *testing.B) {
+				encoded, err := EncodeAction(encoding, action)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportAllocs()
+				b.SetBytes(int64(len(encoded)))
+				for i := 0; i < b.N; i++ {
+					if _, err := EncodeAction(encoding, action); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkDecodeSendPacket compares the controller encodings of a
+// sendPacket downcall.
+func BenchmarkDecodeSendPacket(b *testing.B) {
+	packet := benchPacket()
+
+	b.Run(EncodingJSON, func(b *testing.B) {
+		jsonMsg, err := json.Marshal(&channelMessage{
+			Type:   "IBC_METHOD",
+			Method: "sendPacket",
+			Packet: packet,
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.SetBytes(int64(len(jsonMsg)))
+		for i := 0; i < b.N; i++ {
+			msg := new(channelMessage)
+			if err := json.Unmarshal(jsonMsg, msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run(EncodingProtobuf, func(b *testing.B) {
+		pm := &types.ChannelMessage{
+			Type:   "IBC_METHOD",
+			Method: "sendPacket",
+			Packet: packet,
+		}
+		protoBz, err := pm.Marshal()
+		if err != nil {
+			b.Fatal(err)
+		}
+		protoMsg := encodePortMessage(protoBz)
+		b.ReportAllocs()
+		b.SetBytes(int64(len(protoMsg)))
+		for i := 0; i < b.N; i++ {
+			bz, err := decodePortMessage(protoMsg)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var msg types.ChannelMessage
+			if err := msg.Unmarshal(bz); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// testActions are an upcall of every kind, with every field set.
+func testActions() []interface{} {
+	submitter := sdk.AccAddress([]byte("submitter-address-01"))
+	address := sdk.AccAddress([]byte("provision-address-01"))
+	packet := channeltypes.NewPacket(
+		[]byte("data"), 7, "port-0", "channel-0", "port-1", "channel-1",
+		clienttypes.NewHeight(1, 1000), 1600000100,
+	)
+	counterparty := channeltypes.NewCounterparty("port-1", "channel-1")
+	return []interface{}{
+		&beginBlockAction{Type: "BEGIN_BLOCK", StoragePort: 1, BlockHeight: 10, BlockTime: 1600000000, ChainID: "agoric-test"},
+		&endBlockAction{Type: "END_BLOCK", StoragePort: 1, BlockHeight: 10, BlockTime: 1600000000},
+		&commitBlockAction{Type: "COMMIT_BLOCK", BlockHeight: 10, BlockTime: 1600000000},
+		&deliverInboundAction{
+			Type: "DELIVER_INBOUND", Peer: submitter.String(),
+			Messages: []inboundMessage{{Num: 1, Body: "hello"}, {Num: 1 << 60, Body: ""}},
+			Ack:      3, StoragePort: 1, BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&provisionAction{
+			MsgProvision: &MsgProvision{Nickname: "alice", Address: address, PowerFlags: []string{"agoric.vattp"}, Submitter: submitter},
+			Type:         "PLEASE_PROVISION", BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&exportKernelAction{Type: "EXPORT_KERNEL", StoragePort: 1, BlockHeight: 10, BlockTime: 1600000000},
+		&importKernelAction{
+			Type: "IMPORT_KERNEL", StoragePort: 1, BlockHeight: 1, BlockTime: 1600000000,
+			ChainID: "agoric-test", ExportHeight: 9, Hash: bundleHash([]byte("bundle")), Bundle: []byte("bundle"),
+		},
+		&sendPacketAction{
+			MsgSendPacket: &MsgSendPacket{Packet: packet, Sender: submitter},
+			Type:          "IBC_EVENT", Event: "sendPacket", BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&channelOpenInitEvent{
+			Type: "IBC_EVENT", Event: "channelOpenInit", Order: "ORDERED", ConnectionHops: []string{"connection-0"},
+			PortID: "port-0", ChannelID: "channel-0", Counterparty: counterparty, Version: "v1",
+			BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&channelOpenTryEvent{
+			Type: "IBC_EVENT", Event: "channelOpenTry", Order: "UNORDERED", ConnectionHops: []string{"connection-0"},
+			PortID: "port-0", ChannelID: "channel-0", Counterparty: counterparty, Version: "v1",
+			CounterpartyVersion: "v2", BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&channelOpenAckEvent{
+			Type: "IBC_EVENT", Event: "channelOpenAck", PortID: "port-0", ChannelID: "channel-0",
+			CounterpartyVersion: "v2", BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&channelOpenConfirmEvent{Type: "IBC_EVENT", Event: "channelOpenConfirm", PortID: "port-0", ChannelID: "channel-0", BlockHeight: 10, BlockTime: 1600000000},
+		&channelCloseInitEvent{Type: "IBC_EVENT", Event: "channelCloseInit", PortID: "port-0", ChannelID: "channel-0", BlockHeight: 10, BlockTime: 1600000000},
+		&channelCloseConfirmEvent{Type: "IBC_EVENT", Event: "channelCloseConfirm", PortID: "port-0", ChannelID: "channel-0", BlockHeight: 10, BlockTime: 1600000000},
+		&receivePacketEvent{Type: "IBC_EVENT", Event: "receivePacket", Packet: packet, BlockHeight: 10, BlockTime: 1600000000},
+		&acknowledgementPacketEvent{
+			Type: "IBC_EVENT", Event: "acknowledgementPacket", Packet: packet, Acknowledgement: []byte("ack"),
+			BlockHeight: 10, BlockTime: 1600000000,
+		},
+		&timeoutPacketEvent{Type: "IBC_EVENT", Event: "timeoutPacket", Packet: packet, BlockHeight: 10, BlockTime: 1600000000},
+	}
+}
+
+// actionFromProto decodes an upcall the way a controller would.
+func actionFromProto(ca *types.ControllerAction) (interface{}, error) {
+	switch body := ca.Body.(type) {
+	case nil:
+		return &commitBlockAction{Type: ca.Type, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime}, nil
+	case *types.ControllerAction_BeginBlock:
+		return &beginBlockAction{
+			Type: ca.Type, StoragePort: int(body.BeginBlock.StoragePort),
+			BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime, ChainID: body.BeginBlock.ChainID,
+		}, nil
+	case *types.ControllerAction_EndBlock:
+		return &endBlockAction{
+			Type: ca.Type, StoragePort: int(body.EndBlock.StoragePort),
+			BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+		}, nil
+	case *types.ControllerAction_DeliverInbound:
+		di := body.DeliverInbound
+		messages := make([]inboundMessage, len(di.Messages))
+		for i, m := range di.Messages {
+			messages[i] = inboundMessage{Num: m.Num, Body: m.Body}
+		}
+		return &deliverInboundAction{
+			Type: ca.Type, Peer: di.Peer, Messages: messages, Ack: di.Ack, StoragePort: int(di.StoragePort),
+			BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+		}, nil
+	case *types.ControllerAction_Provision:
+		p := body.Provision
+		return &provisionAction{
+			MsgProvision: &MsgProvision{Nickname: p.Nickname, Address: p.Address, PowerFlags: p.PowerFlags, Submitter: p.Submitter},
+			Type:         ca.Type, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+		}, nil
+	case *types.ControllerAction_ExportKernel:
+		return &exportKernelAction{
+			Type: ca.Type, StoragePort: int(body.ExportKernel.StoragePort),
+			BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+		}, nil
+	case *types.ControllerAction_ImportKernel:
+		ik := body.ImportKernel
+		return &importKernelAction{
+			Type: ca.Type, StoragePort: int(ik.StoragePort), BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			ChainID: ik.ChainID, ExportHeight: ik.ExportHeight, Hash: ik.Hash, Bundle: ik.Bundle,
+		}, nil
+	case *types.ControllerAction_IbcEvent:
+		ev := body.IbcEvent
+		switch ev.Event {
+		case "sendPacket":
+			return &sendPacketAction{
+				MsgSendPacket: &MsgSendPacket{Packet: ev.Packet, Sender: ev.Submitter},
+				Type:          ca.Type, Event: ev.Event, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "channelOpenInit":
+			return &channelOpenInitEvent{
+				Type: ca.Type, Event: ev.Event, Order: ev.Order, ConnectionHops: ev.ConnectionHops,
+				PortID: ev.PortID, ChannelID: ev.ChannelID, Counterparty: ev.Counterparty, Version: ev.Version,
+				BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "channelOpenTry":
+			return &channelOpenTryEvent{
+				Type: ca.Type, Event: ev.Event, Order: ev.Order, ConnectionHops: ev.ConnectionHops,
+				PortID: ev.PortID, ChannelID: ev.ChannelID, Counterparty: ev.Counterparty, Version: ev.Version,
+				CounterpartyVersion: ev.CounterpartyVersion, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "channelOpenAck":
+			return &channelOpenAckEvent{
+				Type: ca.Type, Event: ev.Event, PortID: ev.PortID, ChannelID: ev.ChannelID,
+				CounterpartyVersion: ev.CounterpartyVersion, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "channelOpenConfirm":
+			return &channelOpenConfirmEvent{
+				Type: ca.Type, Event: ev.Event, PortID: ev.PortID, ChannelID: ev.ChannelID,
+				BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "channelCloseInit":
+			return &channelCloseInitEvent{
+				Type: ca.Type, Event: ev.Event, PortID: ev.PortID, ChannelID: ev.ChannelID,
+				BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "channelCloseConfirm":
+			return &channelCloseConfirmEvent{
+				Type: ca.Type, Event: ev.Event, PortID: ev.PortID, ChannelID: ev.ChannelID,
+				BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "receivePacket":
+			return &receivePacketEvent{
+				Type: ca.Type, Event: ev.Event, Packet: ev.Packet, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "acknowledgementPacket":
+			return &acknowledgementPacketEvent{
+				Type: ca.Type, Event: ev.Event, Packet: ev.Packet, Acknowledgement: ev.Acknowledgement,
+				BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		case "timeoutPacket":
+			return &timeoutPacketEvent{
+				Type: ca.Type, Event: ev.Event, Packet: ev.Packet, BlockHeight: ca.BlockHeight, BlockTime: ca.BlockTime,
+			}, nil
+		}
+		return nil, fmt.Errorf("unknown IBC event %q", ev.Event)
+	}
+	return nil, fmt.Errorf("unknown action body %T", ca.Body)
+}
+
+func TestActionRoundTrip(t *testing.T) {
+	for _, action := range testActions() {
+		name := fmt.Sprintf("%T", action)
+		t.Run(strings.TrimPrefix(name, "*swingset."), func(t *testing.T) {
+			encoded, err := EncodeAction(EncodingJSON, action)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ValidateUpcall(encoded); err != nil {
+				t.Errorf("invalid %s: %v", EncodingJSON, err)
+			}
+			decoded := reflect.New(reflect.TypeOf(action).Elem()).Interface()
+			if err := json.Unmarshal([]byte(encoded), decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, action) {
+				t.Errorf("%s decodes to %+v; want %+v", EncodingJSON, decoded, action)
+			}
+
+			encoded, err = EncodeAction(EncodingProtobuf, action)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bz, err := decodePortMessage(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ca types.ControllerAction
+			if err := ca.Unmarshal(bz); err != nil {
+				t.Fatal(err)
+			}
+			decoded, err = actionFromProto(&ca)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, action) {
+				t.Errorf("%s decodes to %+v; want %+v", EncodingProtobuf, decoded, action)
+			}
+		})
+	}
+}
+
+func TestInboundMessageJSON(t *testing.T) {
+	var m inboundMessage
+	if err := json.Unmarshal([]byte(`[18446744073709551615,"body"]`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Num != 18446744073709551615 || m.Body != "body" {
+		t.Errorf("decoded %+v", m)
+	}
+	for _, bad := range []string{`[1]`, `[1,"body",2]`, `["1","body"]`, `[-1,"body"]`, `[1,2]`, `{"num":1}`} {
+		if err := json.Unmarshal([]byte(bad), &m); err == nil {
+			t.Errorf("decoded %s", bad)
+		}
+	}
+}
+
+// storageMessageToProto encodes a storage downcall the way a controller
+// would.
+func storageMessageToProto(msg *storageMessage) *types.StorageMessage {
+	pm := &types.StorageMessage{
+		Method: msg.Method, Key: msg.Key, Value: msg.Value, Keys: msg.Keys,
+		After: msg.After, Limit: msg.Limit,
+	}
+	for _, entry := range msg.Entries {
+		pm.Entries = append(pm.Entries, types.StorageEntry{Key: entry[0], Value: entry[1]})
+	}
+	if msg.Expected != nil {
+		pm.Expected = &types.Storage{Value: *msg.Expected}
+	}
+	return pm
+}
+
+func TestPortMessageRoundTrip(t *testing.T) {
+	expected := "old"
+	empty := ""
+	packet := channeltypes.NewPacket(
+		[]byte("data"), 7, "port-0", "channel-0", "port-1", "channel-1",
+		clienttypes.NewHeight(1, 1000), 1600000100,
+	)
+	storageMessages := []*storageMessage{
+		{Method: "set", Key: "a.b", Value: "value"},
+		{Method: "get", Key: "a.b"},
+		{Method: "setMany", Entries: [][]string{{"a.b", "1"}, {"a.c", ""}}},
+		{Method: "getMany", Keys: []string{"a.b", "a.c"}},
+		{Method: "setIfEqual", Key: "a.b", Value: "new", Expected: &expected},
+		{Method: "setIfEqual", Key: "a.b", Value: "new", Expected: &empty},
+		{Method: "entries", Key: "a", After: "b", Limit: 10},
+	}
+	for _, msg := range storageMessages {
+		t.Run("storage/"+msg.Method, func(t *testing.T) {
+			bz, err := json.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ValidateDowncall("storage", string(bz)); err != nil {
+				t.Errorf("invalid %s: %v", EncodingJSON, err)
+			}
+			decoded := new(storageMessage)
+			if err := json.Unmarshal(bz, decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, msg) {
+				t.Errorf("%s decodes to %+v; want %+v", EncodingJSON, decoded, msg)
+			}
+
+			if bz, err = storageMessageToProto(msg).Marshal(); err != nil {
+				t.Fatal(err)
+			}
+			if bz, err = decodePortMessage(encodePortMessage(bz)); err != nil {
+				t.Fatal(err)
+			}
+			var pm types.StorageMessage
+			if err := pm.Unmarshal(bz); err != nil {
+				t.Fatal(err)
+			}
+			if decoded := storageMessageFromProto(&pm); !reflect.DeepEqual(decoded, msg) {
+				t.Errorf("%s decodes to %+v; want %+v", EncodingProtobuf, decoded, msg)
+			}
+		})
+	}
+
+	msg := &channelMessage{
+		Type: "IBC_METHOD", Method: "sendPacket", Packet: packet, RelativeTimeout: 10,
+		Order: "ORDERED", Hops: []string{"connection-0"}, Version: "v1", Ack: []byte("ack"),
+	}
+	t.Run("dibc", func(t *testing.T) {
+		bz, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateDowncall("dibc", string(bz)); err != nil {
+			t.Errorf("invalid %s: %v", EncodingJSON, err)
+		}
+		decoded := new(channelMessage)
+		if err := json.Unmarshal(bz, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, msg) {
+			t.Errorf("%s decodes to %+v; want %+v", EncodingJSON, decoded, msg)
+		}
+
+		pm := &types.ChannelMessage{
+			Type: msg.Type, Method: msg.Method, Packet: msg.Packet, RelativeTimeout: msg.RelativeTimeout,
+			Order: msg.Order, Hops: msg.Hops, Version: msg.Version, Ack: msg.Ack,
+		}
+		if bz, err = pm.Marshal(); err != nil {
+			t.Fatal(err)
+		}
+		if bz, err = decodePortMessage(encodePortMessage(bz)); err != nil {
+			t.Fatal(err)
+		}
+		var decodedPM types.ChannelMessage
+		if err := decodedPM.Unmarshal(bz); err != nil {
+			t.Fatal(err)
+		}
+		if decoded := channelMessageFromProto(&decodedPM); !reflect.DeepEqual(decoded, msg) {
+			t.Errorf("%s decodes to %+v; want %+v", EncodingProtobuf, decoded, msg)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package swingset
+
+import (
+	"math"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Gas descriptors for storage downcalls.
+const (
+	GasDescStorageRead  = "swingset storage read"
+	GasDescStorageWrite = "swingset storage write"
+	GasDescStorageBytes = "swingset storage bytes"
+)
+
+type storageGasMeterKey struct{}
+
+// WithStorageGasMeter returns ctx with meter charged for the controller's
+// storage downcalls.  The controller's own store accesses are not metered,
+// since they depend on how the kernel lays out its state rather than on what
+// it was asked to do.
+func WithStorageGasMeter(ctx sdk.Context, meter sdk.GasMeter) sdk.Context {
+	return ctx.WithValue(storageGasMeterKey{}, meter).WithGasMeter(sdk.NewInfiniteGasMeter())
+}
+
+// storageGasMeter returns the meter for storage downcalls made in ctx, which
+// is unlimited unless set by WithStorageGasMeter.
+func storageGasMeter(ctx sdk.Context) sdk.GasMeter {
+	// COMMIT_BLOCK is sent with an empty Context, which has no values.
+	if ctx.Context() == nil {
+		return sdk.NewInfiniteGasMeter()
+	}
+	if meter, ok := ctx.Value(storageGasMeterKey{}).(sdk.GasMeter); ok {
+		return meter
+	}
+	return sdk.NewInfiniteGasMeter()
+}
+
+// newBlockGasMeter returns the meter for storage downcalls during BeginBlock
+// and EndBlock.
+func newBlockGasMeter(params types.Params) sdk.GasMeter {
+	if params.BlockStorageGasLimit == 0 {
+		return sdk.NewInfiniteGasMeter()
+	}
+	return sdk.NewGasMeter(params.BlockStorageGasLimit)
+}
+
+// storageGas charges a storage downcall to its frame's gas meter.  It panics
+// with sdk.ErrorOutOfGas when the meter runs out.
+type storageGas struct {
+	meter    sdk.GasMeter
+	schedule types.StorageGas
+}
+
+func newStorageGas(ctx *ControllerContext) storageGas {
+	return storageGas{
+		meter:    ctx.GasMeter,
+		schedule: ctx.Keeper.GetParams(ctx.Context).StorageGas,
+	}
+}
+
+func (g storageGas) reads(n int) {
+	g.consume(g.schedule.Read, n, GasDescStorageRead)
+}
+
+func (g storageGas) writes(n int) {
+	g.consume(g.schedule.Write, n, GasDescStorageWrite)
+}
+
+func (g storageGas) bytes(n int) {
+	g.consume(g.schedule.PerByte, n, GasDescStorageBytes)
+}
+
+func (g storageGas) consume(cost uint64, n int, descriptor string) {
+	if cost == 0 || n == 0 {
+		return
+	}
+	gas := sdk.Gas(n) * cost
+	if cost > math.MaxUint64/uint64(n) {
+		// Saturate rather than overflow the meter.
+		gas = math.MaxUint64 - g.meter.GasConsumed()
+	}
+	g.meter.ConsumeGas(gas, descriptor)
+}
+
+// checkGas returns an error if the storage downcalls during an upcall ran
+// meter out of gas, even if the controller caught the failed downcall.
+func checkGas(meter sdk.GasMeter) error {
+	if !meter.IsPastLimit() {
+		return nil
+	}
+	return sdkerrors.Wrapf(
+		sdkerrors.ErrOutOfGas, "swingset storage used %d of %d gas",
+		meter.GasConsumed(), meter.Limit(),
+	)
+}
@@ -1,7 +1,14 @@
 package swingset
 
 import (
-	// "fmt"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,30 +18,238 @@ import (
 func NewGenesisState() *types.GenesisState {
 	return &types.GenesisState{
 		Storage: make(map[string]string),
+		Params:  types.DefaultParams(),
 	}
 }
 
+// ValidateGenesis checks the params and every storage entry, and reports all
+// the problems it finds, each with its path.
 func ValidateGenesis(data *types.GenesisState) error {
+	var problems []string
+	if err := data.Params.ValidateBasic(); err != nil {
+		problems = append(problems, fmt.Sprintf("params: %s", err))
+	}
+	if data.StorageFiles != nil {
+		if len(data.Storage) > 0 {
+			problems = append(problems, "genesis cannot have both storage and storage files")
+		}
+		if err := validateStorageFiles(data.StorageFiles); err != nil {
+			problems = append(problems, fmt.Sprintf("storage files: %s", err))
+		}
+	}
+	if data.KernelExport != nil {
+		if err := validateKernelExport(data.KernelExport); err != nil {
+			problems = append(problems, fmt.Sprintf("kernel export: %s", err))
+		}
+	}
+
+	paths := make([]string, 0, len(data.Storage))
+	for path := range data.Storage {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := validateStorageEntry(path, data.Storage[path]); err != nil {
+			problems = append(problems, fmt.Sprintf("storage %q: %s", path, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid swingset genesis:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// validateStorageEntry checks the path of a genesis storage entry, and the
+// value of the entries that the module itself reads.
+func validateStorageEntry(path, value string) error {
+	if err := types.ValidatePath(path); err != nil {
+		return err
+	}
+	segments := types.PathSegments(path)
+	if len(segments) != 2 {
+		return nil
+	}
+	switch segments[0] {
+	case "mailbox":
+		return validateMailbox(segments[1], value)
+	case "egress":
+		return validateEgress(segments[1], value)
+	}
+	return nil
+}
+
+// validateMailbox checks a peer's mailbox, which is the JSON
+// {"outbox": [[num, body], ...], "ack": num} written by the controller.
+func validateMailbox(peer, value string) error {
+	if _, err := sdk.AccAddressFromBech32(peer); err != nil {
+		return fmt.Errorf("mailbox peer: %w", err)
+	}
+	var mailbox struct {
+		Outbox []json.RawMessage `json:"outbox"`
+		Ack    json.RawMessage   `json:"ack"`
+	}
+	if err := json.Unmarshal([]byte(value), &mailbox); err != nil {
+		return fmt.Errorf("mailbox is not JSON: %w", err)
+	}
+	if mailbox.Outbox == nil {
+		return fmt.Errorf("mailbox has no outbox")
+	}
+	if err := validateMailboxNum(mailbox.Ack); err != nil {
+		return fmt.Errorf("mailbox ack: %w", err)
+	}
+	for i, raw := range mailbox.Outbox {
+		var message []json.RawMessage
+		if err := json.Unmarshal(raw, &message); err != nil || len(message) != 2 {
+			return fmt.Errorf("mailbox message %d is not a [num, body] pair", i)
+		}
+		if err := validateMailboxNum(message[0]); err != nil {
+			return fmt.Errorf("mailbox message %d num: %w", i, err)
+		}
+		var body string
+		if err := json.Unmarshal(message[1], &body); err != nil {
+			return fmt.Errorf("mailbox message %d body is not a string", i)
+		}
+	}
+	return nil
+}
+
+// validateMailboxNum checks a mailbox number, which the controller may write
+// as a JSON number or a decimal string.
+func validateMailboxNum(raw json.RawMessage) error {
+	var num float64
+	if err := json.Unmarshal(raw, &num); err == nil {
+		if num < 0 {
+			return fmt.Errorf("%s is negative", raw)
+		}
+		_, err = types.Nat(num)
+		return err
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return fmt.Errorf("%s is not a number", raw)
+	}
+	if _, err := strconv.ParseUint(str, 10, 64); err != nil {
+		return fmt.Errorf("%q is not a natural number", str)
+	}
+	return nil
+}
+
+// validateEgress checks that a peer's egress is the JSON of its types.Egress.
+func validateEgress(peer, value string) error {
+	addr, err := sdk.AccAddressFromBech32(peer)
+	if err != nil {
+		return fmt.Errorf("egress peer: %w", err)
+	}
+	var egress types.Egress
+	if err := json.Unmarshal([]byte(value), &egress); err != nil {
+		return fmt.Errorf("egress is not a valid Egress: %w", err)
+	}
+	if !egress.Peer.Equals(addr) {
+		return fmt.Errorf("egress is for peer %s", egress.Peer)
+	}
+	return nil
+}
+
+// validateStorageFiles checks the description of genesis storage files,
+// which cannot be read until InitGenesis.
+func validateStorageFiles(files *types.StorageFiles) error {
+	for _, name := range files.Files {
+		if name == "" || filepath.Base(name) != name || name == "." || name == ".." {
+			return fmt.Errorf("file %q is not a plain file name", name)
+		}
+	}
+	if files.Entries > 0 && len(files.Files) == 0 {
+		return fmt.Errorf("%d entries in no files", files.Entries)
+	}
+	if bz, err := hex.DecodeString(files.Checksum); err != nil || len(bz) != sha256.Size {
+		return fmt.Errorf("checksum %q is not a hex SHA-256", files.Checksum)
+	}
+	return nil
+}
+
+// validateKernelExport checks a kernel export, whose bundle file cannot be
+// read until InitGenesis.
+func validateKernelExport(export *types.KernelExport) error {
+	if !isBundleHash(export.Hash) {
+		return fmt.Errorf("hash %q is not a hex SHA-256", export.Hash)
+	}
+	if export.BlockHeight < 0 {
+		return fmt.Errorf("negative block height %d", export.BlockHeight)
+	}
+	if export.File != "" {
+		if export.Bundle != nil {
+			return fmt.Errorf("cannot have both a bundle and a file")
+		}
+		if filepath.Base(export.File) != export.File || export.File == "." || export.File == ".." {
+			return fmt.Errorf("file %q is not a plain file name", export.File)
+		}
+	}
+	if export.Bundle != nil && bundleHash(export.Bundle) != export.Hash {
+		return fmt.Errorf("bundle has hash %s, expected %s", bundleHash(export.Bundle), export.Hash)
+	}
 	return nil
 }
 
 func DefaultGenesisState() *types.GenesisState {
 	return &types.GenesisState{
 		Storage: make(map[string]string),
+		Params:  types.DefaultParams(),
 	}
 }
 
-func InitGenesis(ctx sdk.Context, keeper Keeper, data *types.GenesisState) []abci.ValidatorUpdate {
-	var storage types.Storage
+// InitGenesis sets the genesis state, reading any storage files from
+// storageDir, and then hands any kernel export back to the controller.
+func InitGenesis(ctx sdk.Context, keeper Keeper, controller *Controller, data *types.GenesisState, storageDir string) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
+
 	for key, value := range data.Storage {
-		storage.Value = value
-		keeper.SetStorage(ctx, key, &storage)
+		keeper.SetStorageBytes(ctx, key, []byte(value))
+	}
+	if data.StorageFiles != nil {
+		if err := ImportStorageFiles(ctx, keeper, storageDir, data.StorageFiles); err != nil {
+			panic(fmt.Errorf("cannot import SwingSet storage: %w", err))
+		}
+	}
+	// The keys index is not in genesis, so check the one it built.
+	if err := keeper.CheckKeysIndex(ctx); err != nil {
+		panic(fmt.Errorf("invalid SwingSet storage from genesis: %w", err))
+	}
+	if data.KernelExport != nil {
+		if err := ImportKernel(ctx, keeper, controller, data.KernelExport, storageDir); err != nil {
+			panic(fmt.Errorf("cannot import SwingSet kernel: %w", err))
+		}
 	}
 	return []abci.ValidatorUpdate{}
 }
 
-func ExportGenesis(ctx sdk.Context, k Keeper) *types.GenesisState {
+// ExportGenesis returns the genesis state, including the controller's kernel
+// export, with the storage and kernel bundle written to files if files has an
+// ExportDir.
+func ExportGenesis(ctx sdk.Context, k Keeper, controller *Controller, files *StorageFilesConfig) *types.GenesisState {
+	// Don't export storage whose keys index cannot be trusted.
+	if err := k.CheckKeysIndex(ctx); err != nil {
+		panic(fmt.Errorf("cannot export SwingSet storage: %w", err))
+	}
 	gs := NewGenesisState()
-	gs.Storage = k.ExportStorage(ctx)
+	var exportDir string
+	if files != nil {
+		exportDir = files.ExportDir
+	}
+	if exportDir != "" {
+		storageFiles, err := ExportStorageFiles(ctx, k, exportDir, files.EntriesPerFile)
+		if err != nil {
+			panic(fmt.Errorf("cannot export SwingSet storage: %w", err))
+		}
+		gs.StorageFiles = storageFiles
+	} else {
+		gs.Storage = k.ExportStorage(ctx)
+	}
+	kernelExport, err := ExportKernel(ctx, k, controller, exportDir)
+	if err != nil {
+		panic(fmt.Errorf("cannot export SwingSet kernel: %w", err))
+	}
+	gs.KernelExport = kernelExport
+	gs.Params = k.GetParams(ctx)
 	return gs
 }
@@ -0,0 +1,205 @@
+package swingset
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultStorageEntriesPerFile is the most storage entries that an export
+// writes to one file.
+const DefaultStorageEntriesPerFile = 100000
+
+// StorageFilesConfig locates the storage files that accompany genesis.json.
+type StorageFilesConfig struct {
+	// Dir is where InitGenesis reads storage and kernel bundle files, normally
+	// the directory of genesis.json.
+	Dir string
+	// ExportDir, if set, makes ExportGenesis write the storage and kernel
+	// bundle to files there, rather than include them in the genesis state.
+	ExportDir string
+	// EntriesPerFile is the most entries ExportGenesis writes to one file.
+	EntriesPerFile int
+}
+
+// storageRecord is one line of a storage file.  A value that is not UTF-8 is
+// written as base64 Bytes.
+type storageRecord struct {
+	Path  string  `json:"path"`
+	Value *string `json:"value,omitempty"`
+	Bytes []byte  `json:"bytes,omitempty"`
+}
+
+func newStorageRecord(path string, value []byte) (*storageRecord, error) {
+	if !utf8.ValidString(path) {
+		return nil, fmt.Errorf("storage path %q is not UTF-8", path)
+	}
+	if !utf8.Valid(value) {
+		return &storageRecord{Path: path, Bytes: value}, nil
+	}
+	s := string(value)
+	return &storageRecord{Path: path, Value: &s}, nil
+}
+
+func (r *storageRecord) value() ([]byte, error) {
+	switch {
+	case r.Value != nil && r.Bytes == nil:
+		return []byte(*r.Value), nil
+	case r.Value == nil && r.Bytes != nil:
+		return r.Bytes, nil
+	}
+	return nil, fmt.Errorf("storage %s needs exactly one of value and bytes", r.Path)
+}
+
+// storageChecksum hashes storage entries in order, independently of how
+// they are split into files.
+type storageChecksum struct {
+	hash    hash.Hash
+	entries uint64
+}
+
+func newStorageChecksum() *storageChecksum {
+	return &storageChecksum{hash: sha256.New()}
+}
+
+func (c *storageChecksum) add(path string, value []byte) {
+	var n [binary.MaxVarintLen64]byte
+	c.hash.Write(n[:binary.PutUvarint(n[:], uint64(len(path)))])
+	c.hash.Write([]byte(path))
+	c.hash.Write(n[:binary.PutUvarint(n[:], uint64(len(value)))])
+	c.hash.Write(value)
+	c.entries++
+}
+
+func (c *storageChecksum) String() string {
+	return hex.EncodeToString(c.hash.Sum(nil))
+}
+
+// storageFileName is the name of the nth storage file.
+func storageFileName(n int) string {
+	return fmt.Sprintf("swingset-storage-%05d.jsonl", n)
+}
+
+// ExportStorageFiles writes the storage, in path order, to JSON Lines files in
+// dir of at most entriesPerFile entries each, and returns their description.
+func ExportStorageFiles(ctx sdk.Context, k Keeper, dir string, entriesPerFile int) (*types.StorageFiles, error) {
+	if entriesPerFile <= 0 {
+		entriesPerFile = DefaultStorageEntriesPerFile
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	files := &types.StorageFiles{}
+	checksum := newStorageChecksum()
+	var file *os.File
+	var w *bufio.Writer
+	closeFile := func() error {
+		if file == nil {
+			return nil
+		}
+		err := w.Flush()
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		file = nil
+		return err
+	}
+
+	var err error
+	k.IterateStorage(ctx, func(path string, value []byte) bool {
+		if checksum.entries%uint64(entriesPerFile) == 0 {
+			if err = closeFile(); err != nil {
+				return true
+			}
+			name := storageFileName(len(files.Files))
+			if file, err = os.Create(filepath.Join(dir, name)); err != nil {
+				return true
+			}
+			w = bufio.NewWriter(file)
+			files.Files = append(files.Files, name)
+		}
+		var record *storageRecord
+		if record, err = newStorageRecord(path, value); err != nil {
+			return true
+		}
+		var bz []byte
+		if bz, err = json.Marshal(record); err != nil {
+			return true
+		}
+		if _, err = w.Write(append(bz, '\n')); err != nil {
+			return true
+		}
+		checksum.add(path, value)
+		return false
+	})
+	if cerr := closeFile(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files.Entries = checksum.entries
+	files.Checksum = checksum.String()
+	fmt.Fprintf(os.Stderr, "Exported %d SwingSet storage entries to %d files in %s, checksum %s\n",
+		files.Entries, len(files.Files), dir, files.Checksum)
+	return files, nil
+}
+
+// ImportStorageFiles sets the storage from the files in dir, one entry at a
+// time, and checks that they match their description.  Like the entries of
+// inline genesis storage, each one emits a storage event.
+func ImportStorageFiles(ctx sdk.Context, k Keeper, dir string, files *types.StorageFiles) error {
+	checksum := newStorageChecksum()
+	for _, name := range files.Files {
+		if err := importStorageFile(ctx, k, filepath.Join(dir, name), checksum); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported %d SwingSet storage entries from %d files in %s, checksum %s\n",
+		checksum.entries, len(files.Files), dir, checksum)
+	if checksum.entries != files.Entries {
+		return fmt.Errorf("storage files have %d entries, expected %d", checksum.entries, files.Entries)
+	}
+	if checksum.String() != files.Checksum {
+		return fmt.Errorf("storage files have checksum %s, expected %s", checksum, files.Checksum)
+	}
+	return nil
+}
+
+func importStorageFile(ctx sdk.Context, k Keeper, name string, checksum *storageChecksum) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for {
+		var record storageRecord
+		if err := decoder.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		value, err := record.value()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		k.SetStorageBytes(ctx, record.Path, value)
+		checksum.add(record.Path, value)
+	}
+}
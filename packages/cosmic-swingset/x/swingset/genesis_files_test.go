@@ -0,0 +1,124 @@
+package swingset
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// testStorage has an escaped path, an empty value and one that is not UTF-8.
+var testStorage = map[string][]byte{
+	"a":         []byte("1"),
+	"a.b":       []byte(""),
+	`a.c\.d`:    []byte("2"),
+	"bin":       {0xff, 0xfe},
+	"mailbox.x": []byte(`{"outbox":[],"ack":0}`),
+}
+
+func exportTestStorageFiles(t *testing.T) (string, *types.StorageFiles) {
+	dir, err := ioutil.TempDir("", "swingset-storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	ctx := newTestControllerContext(t, true)
+	for path, value := range testStorage {
+		ctx.Keeper.SetStorageBytes(ctx.Context, path, value)
+	}
+	files, err := ExportStorageFiles(ctx.Context, *ctx.Keeper, dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, files
+}
+
+func TestStorageFilesRoundTrip(t *testing.T) {
+	dir, files := exportTestStorageFiles(t)
+	if files.Entries != uint64(len(testStorage)) || len(files.Files) != 3 {
+		t.Fatalf("exported %d entries to files %q", files.Entries, files.Files)
+	}
+
+	ctx := newTestControllerContext(t, true)
+	ctx.Context = ctx.Context.WithEventManager(sdk.NewEventManager())
+	if err := ImportStorageFiles(ctx.Context, *ctx.Keeper, dir, files); err != nil {
+		t.Fatal(err)
+	}
+	for path, value := range testStorage {
+		got, ok := ctx.Keeper.GetStorageBytes(ctx.Context, path)
+		if !ok || string(got) != string(value) {
+			t.Errorf("imported %s is %q, %v; expected %q", path, got, ok, value)
+		}
+	}
+	if err := ctx.Keeper.CheckKeysIndex(ctx.Context); err != nil {
+		t.Error(err)
+	}
+
+	// The import emits the same events as inline genesis storage.
+	inline := newTestControllerContext(t, true)
+	inline.Context = inline.Context.WithEventManager(sdk.NewEventManager())
+	gs := DefaultGenesisState()
+	for path, value := range testStorage {
+		gs.Storage[path] = string(value)
+	}
+	InitGenesis(inline.Context, *inline.Keeper, nil, gs, "")
+	countSets := func(events sdk.Events) int {
+		n := 0
+		for _, event := range events {
+			if event.Type == types.EventTypeStorage {
+				n++
+			}
+		}
+		return n
+	}
+	if n, inlineN := countSets(ctx.Context.EventManager().Events()), countSets(inline.Context.EventManager().Events()); n != len(testStorage) || n != inlineN {
+		t.Errorf("files emitted %d storage events and inline storage %d; expected %d", n, inlineN, len(testStorage))
+	}
+}
+
+func TestStorageFilesMismatch(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(dir string, files *types.StorageFiles)
+		err    string
+	}{
+		{"checksum", func(_ string, files *types.StorageFiles) {
+			files.Checksum = strings.Repeat("0", 64)
+		}, "checksum"},
+		{"entries", func(_ string, files *types.StorageFiles) {
+			files.Entries++
+		}, "entries"},
+		{"missing file", func(_ string, files *types.StorageFiles) {
+			files.Files = files.Files[1:]
+		}, "entries"},
+		{"edited value", func(dir string, files *types.StorageFiles) {
+			name := filepath.Join(dir, files.Files[0])
+			bz, err := ioutil.ReadFile(name)
+			if err == nil {
+				err = ioutil.WriteFile(name, []byte(strings.Replace(string(bz), `"1"`, `"9"`, 1)), 0644)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}, "checksum"},
+		{"malformed record", func(dir string, files *types.StorageFiles) {
+			if err := ioutil.WriteFile(filepath.Join(dir, files.Files[0]), []byte(`{"path":"a"}`+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}, "exactly one of value and bytes"},
+	} {
+		dir, files := exportTestStorageFiles(t)
+		tc.modify(dir, files)
+		ctx := newTestControllerContext(t, true)
+		err := ImportStorageFiles(ctx.Context, *ctx.Keeper, dir, files)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: import gave %v; expected %q", tc.name, err, tc.err)
+		}
+	}
+}
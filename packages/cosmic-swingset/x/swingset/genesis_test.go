@@ -0,0 +1,175 @@
+package swingset
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+var (
+	genesisPeer  = sdk.AccAddress([]byte("genesis-peer-address"))
+	genesisOther = sdk.AccAddress([]byte("genesis-other-addres"))
+	genesisHash  = strings.Repeat("ab", 32)
+)
+
+// validGenesis returns a genesis state with one of every kind of entry that
+// ValidateGenesis checks.
+func validGenesis(t *testing.T) *types.GenesisState {
+	egress, err := json.Marshal(types.Egress{Nickname: "peer", Peer: genesisPeer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := DefaultGenesisState()
+	gs.Storage["mailbox."+genesisPeer.String()] = `{"outbox":[[1,"hello"],["2","world"]],"ack":3}`
+	gs.Storage["egress."+genesisPeer.String()] = string(egress)
+	gs.Storage[`activityhash`] = "anything"
+	gs.Storage[`data.a\.b`] = "anything"
+	gs.KernelExport = &types.KernelExport{
+		BlockHeight: 10,
+		Hash:        bundleHash([]byte("bundle")),
+		Bundle:      []byte("bundle"),
+	}
+	return gs
+}
+
+func TestValidateGenesis(t *testing.T) {
+	if err := ValidateGenesis(validGenesis(t)); err != nil {
+		t.Fatalf("valid genesis: %v", err)
+	}
+	if err := ValidateGenesis(DefaultGenesisState()); err != nil {
+		t.Fatalf("default genesis: %v", err)
+	}
+
+	mailbox := "mailbox." + genesisPeer.String()
+	egress := "egress." + genesisPeer.String()
+	tests := []struct {
+		name   string
+		modify func(gs *types.GenesisState)
+		want   string
+	}{
+		{"quota without max bytes", func(gs *types.GenesisState) {
+			gs.Params.StorageQuotas = []types.StorageQuota{{Path: "data"}}
+		}, "must have max_bytes"},
+		{"duplicate quota", func(gs *types.GenesisState) {
+			gs.Params.StorageQuotas = []types.StorageQuota{{Path: "data", MaxBytes: 1}, {Path: "data", MaxBytes: 2}}
+		}, "duplicate storage quota"},
+
+		{"root path", func(gs *types.GenesisState) {
+			gs.Storage[""] = "x"
+		}, "root path"},
+		{"empty segment", func(gs *types.GenesisState) {
+			gs.Storage["data..x"] = "x"
+		}, "empty segment"},
+		{"non-canonical path", func(gs *types.GenesisState) {
+			gs.Storage[`data.a\b`] = "x"
+		}, `should be written "data.ab"`},
+
+		{"mailbox peer", func(gs *types.GenesisState) {
+			gs.Storage["mailbox.nobody"] = `{"outbox":[],"ack":0}`
+		}, "mailbox peer"},
+		{"mailbox not JSON", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":`
+		}, "mailbox is not JSON"},
+		{"mailbox without outbox", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"ack":0}`
+		}, "no outbox"},
+		{"negative ack", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":[],"ack":-1}`
+		}, "mailbox ack: -1 is negative"},
+		{"fractional ack", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":[],"ack":1.5}`
+		}, "mailbox ack"},
+		{"ack string", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":[],"ack":"one"}`
+		}, "not a natural number"},
+		{"message not a pair", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":[[1,"a"],[2]],"ack":0}`
+		}, "mailbox message 1 is not a [num, body] pair"},
+		{"message num", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":[[true,"a"]],"ack":0}`
+		}, "mailbox message 0 num"},
+		{"message body", func(gs *types.GenesisState) {
+			gs.Storage[mailbox] = `{"outbox":[[1,{}]],"ack":0}`
+		}, "mailbox message 0 body is not a string"},
+
+		{"egress peer", func(gs *types.GenesisState) {
+			gs.Storage["egress.nobody"] = gs.Storage[egress]
+		}, "egress peer"},
+		{"egress not JSON", func(gs *types.GenesisState) {
+			gs.Storage[egress] = `[]`
+		}, "not a valid Egress"},
+		{"egress for another peer", func(gs *types.GenesisState) {
+			gs.Storage[egress] = `{"nickname":"peer","peer":"` + genesisOther.String() + `"}`
+		}, "egress is for peer " + genesisOther.String()},
+
+		{"storage and storage files", func(gs *types.GenesisState) {
+			gs.StorageFiles = &types.StorageFiles{Files: []string{"storage-0.json"}, Entries: 1, Checksum: genesisHash}
+		}, "both storage and storage files"},
+		{"storage file path", func(gs *types.GenesisState) {
+			gs.Storage = nil
+			gs.StorageFiles = &types.StorageFiles{Files: []string{"../storage-0.json"}, Entries: 1, Checksum: genesisHash}
+		}, `file "../storage-0.json" is not a plain file name`},
+		{"storage entries without files", func(gs *types.GenesisState) {
+			gs.Storage = nil
+			gs.StorageFiles = &types.StorageFiles{Entries: 3, Checksum: genesisHash}
+		}, "3 entries in no files"},
+		{"storage checksum", func(gs *types.GenesisState) {
+			gs.Storage = nil
+			gs.StorageFiles = &types.StorageFiles{Files: []string{"storage-0.json"}, Entries: 1, Checksum: "abcd"}
+		}, `checksum "abcd" is not a hex SHA-256`},
+
+		{"kernel export hash", func(gs *types.GenesisState) {
+			gs.KernelExport.Hash = "not-a-hash"
+		}, `kernel export: hash "not-a-hash"`},
+		{"kernel export height", func(gs *types.GenesisState) {
+			gs.KernelExport.BlockHeight = -1
+		}, "negative block height -1"},
+		{"kernel export bundle and file", func(gs *types.GenesisState) {
+			gs.KernelExport.File = "kernel.json"
+		}, "both a bundle and a file"},
+		{"kernel export file path", func(gs *types.GenesisState) {
+			gs.KernelExport.Bundle = nil
+			gs.KernelExport.File = "dir/kernel.json"
+		}, `file "dir/kernel.json" is not a plain file name`},
+		{"kernel export bundle hash", func(gs *types.GenesisState) {
+			gs.KernelExport.Bundle = []byte("another bundle")
+		}, "bundle has hash " + bundleHash([]byte("another bundle"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := validGenesis(t)
+			tt.modify(gs)
+			err := ValidateGenesis(gs)
+			if err == nil {
+				t.Fatal("invalid genesis was accepted")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v; want %q", err, tt.want)
+			}
+			// Only the bad field is reported.
+			if problems := strings.Count(err.Error(), "\n  "); problems != 1 {
+				t.Errorf("got %d problems: %v", problems, err)
+			}
+		})
+	}
+}
+
+func TestValidateGenesisReportsEveryProblem(t *testing.T) {
+	gs := validGenesis(t)
+	gs.Storage["data..x"] = "x"
+	gs.Storage["mailbox.nobody"] = "{}"
+	gs.KernelExport.BlockHeight = -1
+	err := ValidateGenesis(gs)
+	if err == nil {
+		t.Fatal("invalid genesis was accepted")
+	}
+	for _, want := range []string{`storage "data..x"`, `storage "mailbox.nobody"`, "kernel export:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("%v does not report %s", err, want)
+		}
+	}
+}
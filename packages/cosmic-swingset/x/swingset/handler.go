@@ -13,40 +13,79 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
-var committedHeight int64 = 0
-
 type deliverInboundAction struct {
-	Type        string          `json:"type"`
-	Peer        string          `json:"peer"`
-	Messages    [][]interface{} `json:"messages"`
-	Ack         uint64          `json:"ack"`
-	StoragePort int             `json:"storagePort"`
-	BlockHeight int64           `json:"blockHeight"`
-	BlockTime   int64           `json:"blockTime"`
+	Type        string           `json:"type"`
+	Peer        string           `json:"peer"`
+	Messages    []inboundMessage `json:"messages"`
+	Ack         uint64           `json:"ack"`
+	StoragePort int              `json:"storagePort"`
+	BlockHeight int64            `json:"blockHeight"`
+	BlockTime   int64            `json:"blockTime"`
+}
+
+// inboundMessage is a numbered mailbox message, which the controller
+// receives as the pair [num, body].
+type inboundMessage struct {
+	Num  uint64
+	Body string
+}
+
+func (m inboundMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{m.Num, m.Body})
+}
+
+func (m *inboundMessage) UnmarshalJSON(bz []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(bz, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("inbound message has %d elements, not [num, body]", len(pair))
+	}
+	if err := json.Unmarshal(pair[0], &m.Num); err != nil {
+		return fmt.Errorf("inbound message num: %w", err)
+	}
+	if err := json.Unmarshal(pair[1], &m.Body); err != nil {
+		return fmt.Errorf("inbound message body: %w", err)
+	}
+	return nil
+}
+
+func (inboundMessage) jsonSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "array",
+		"items": []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "string"},
+		},
+		"minItems": 2,
+		"maxItems": 2,
+	}
 }
 
 // NewHandler returns a handler for "swingset" type messages.
-func NewHandler(keeper Keeper) sdk.Handler {
+func NewHandler(keeper Keeper, controller *Controller) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		if committedHeight == ctx.BlockHeight() {
+		if controller.committedHeight == ctx.BlockHeight() {
 			// We don't support simulation.
 			return &sdk.Result{}, nil
 		} else {
-			// The simulation was done, so now allow infinite gas.
-			ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			// The simulation was done, so charge only storage downcalls to the
+			// transaction's gas.
+			ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 		}
 
 		switch msg := msg.(type) {
 		// Legacy deliver inbound.
 		// TODO: Sometime merge with IBC?
 		case *MsgDeliverInbound:
-			return handleMsgDeliverInbound(ctx, keeper, msg)
+			return handleMsgDeliverInbound(ctx, keeper, controller, msg)
 
 		case *MsgSendPacket:
-			return handleMsgSendPacket(ctx, keeper, msg)
+			return handleMsgSendPacket(ctx, keeper, controller, msg)
 
 		case *MsgProvision:
-			return handleMsgProvision(ctx, keeper, msg)
+			return handleMsgProvision(ctx, keeper, controller, msg)
 
 		default:
 			errMsg := fmt.Sprintf("Unrecognized swingset Msg type: %v", msg.Type())
@@ -63,12 +102,10 @@ func mailboxPeer(key string) (string, error) {
 	return path[1], nil
 }
 
-func handleMsgDeliverInbound(ctx sdk.Context, keeper Keeper, msg *MsgDeliverInbound) (*sdk.Result, error) {
-	messages := make([][]interface{}, len(msg.Messages))
+func handleMsgDeliverInbound(ctx sdk.Context, keeper Keeper, controller *Controller, msg *MsgDeliverInbound) (*sdk.Result, error) {
+	messages := make([]inboundMessage, len(msg.Messages))
 	for i, message := range msg.Messages {
-		messages[i] = make([]interface{}, 2)
-		messages[i][0] = msg.Nums[i]
-		messages[i][1] = message
+		messages[i] = inboundMessage{Num: msg.Nums[i], Body: message}
 	}
 
 	action := &deliverInboundAction{
@@ -76,17 +113,17 @@ func handleMsgDeliverInbound(ctx sdk.Context, keeper Keeper, msg *MsgDeliverInbo
 		Peer:        msg.Submitter.String(),
 		Messages:    messages,
 		Ack:         msg.Ack,
-		StoragePort: GetPort("controller"),
+		StoragePort: controller.GetPort("controller"),
 		BlockHeight: ctx.BlockHeight(),
 		BlockTime:   ctx.BlockTime().Unix(),
 	}
 	// fmt.Fprintf(os.Stderr, "Context is %+v\n", ctx)
-	b, err := json.Marshal(action)
+	b, err := controller.EncodeAction(action)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	_, err = keeper.CallToController(ctx, string(b))
+	_, err = keeper.CallToController(ctx, action.Type, b)
 	// fmt.Fprintln(os.Stderr, "Returned from SwingSet", out, err)
 	if err != nil {
 		return nil, err
@@ -104,7 +141,7 @@ type sendPacketAction struct {
 	BlockTime   int64  `json:"blockTime"`
 }
 
-func handleMsgSendPacket(ctx sdk.Context, keeper Keeper, msg *MsgSendPacket) (*sdk.Result, error) {
+func handleMsgSendPacket(ctx sdk.Context, keeper Keeper, controller *Controller, msg *MsgSendPacket) (*sdk.Result, error) {
 	onePass := sdk.NewInt64Coin("sendpacketpass", 1)
 	balance := keeper.GetBalance(ctx, msg.Sender, onePass.Denom)
 	if balance.IsLT(onePass) {
@@ -122,12 +159,12 @@ func handleMsgSendPacket(ctx sdk.Context, keeper Keeper, msg *MsgSendPacket) (*s
 		BlockTime:     ctx.BlockTime().Unix(),
 	}
 	// fmt.Fprintf(os.Stderr, "Context is %+v\n", ctx)
-	b, err := json.Marshal(action)
+	b, err := controller.EncodeAction(action)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	_, err = keeper.CallToController(ctx, string(b))
+	_, err = keeper.CallToController(ctx, action.Type+"/"+action.Event, b)
 	// fmt.Fprintln(os.Stderr, "Returned from SwingSet", out, err)
 	if err != nil {
 		return nil, err
@@ -144,7 +181,7 @@ type provisionAction struct {
 	BlockTime   int64  `json:"blockTime"`
 }
 
-func handleMsgProvision(ctx sdk.Context, keeper Keeper, msg *MsgProvision) (*sdk.Result, error) {
+func handleMsgProvision(ctx sdk.Context, keeper Keeper, controller *Controller, msg *MsgProvision) (*sdk.Result, error) {
 	onePass := sdk.NewInt64Coin("provisionpass", 1)
 	balance := keeper.GetBalance(ctx, msg.Submitter, onePass.Denom)
 	if balance.IsLT(onePass) {
@@ -161,7 +198,7 @@ func handleMsgProvision(ctx sdk.Context, keeper Keeper, msg *MsgProvision) (*sdk
 		BlockTime:    ctx.BlockTime().Unix(),
 	}
 	// fmt.Fprintf(os.Stderr, "Context is %+v\n", ctx)
-	b, err := json.Marshal(action)
+	b, err := controller.EncodeAction(action)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
@@ -173,7 +210,7 @@ func handleMsgProvision(ctx sdk.Context, keeper Keeper, msg *MsgProvision) (*sdk
 		return nil, err
 	}
 
-	_, err = keeper.CallToController(ctx, string(b))
+	_, err = keeper.CallToController(ctx, action.Type, b)
 	// fmt.Fprintln(os.Stderr, "Returned from SwingSet", out, err)
 	if err != nil {
 		return nil, err
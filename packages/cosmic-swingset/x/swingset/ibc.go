@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	capability "github.com/cosmos/cosmos-sdk/x/capability/types"
 	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
@@ -71,7 +72,19 @@ func (ch channelHandler) Receive(ctx *ControllerContext, str string) (ret string
 	if err != nil {
 		return ret, err
 	}
+	return ch.receive(ctx, msg)
+}
+
+// ReceiveProto handles a binary-encoded types.ChannelMessage.
+func (ch channelHandler) ReceiveProto(ctx *ControllerContext, bz []byte) (string, error) {
+	var pm types.ChannelMessage
+	if err := pm.Unmarshal(bz); err != nil {
+		return "", err
+	}
+	return ch.receive(ctx, channelMessageFromProto(&pm))
+}
 
+func (ch channelHandler) receive(ctx *ControllerContext, msg *channelMessage) (ret string, err error) {
 	if msg.Type != "IBC_METHOD" {
 		return "", fmt.Errorf(`Channel handler only accepts messages of "type": "IBC_METHOD"`)
 	}
@@ -166,9 +179,9 @@ func (ch channelHandler) Receive(ctx *ControllerContext, str string) (ret string
 	return
 }
 
-func (am AppModule) CallToController(ctx sdk.Context, send string) (string, error) {
+func (am AppModule) CallToController(ctx sdk.Context, actionType, send string) (string, error) {
 	// fmt.Println("ibc.go upcall", send)
-	reply, err := am.keeper.CallToController(ctx, send)
+	reply, err := am.keeper.CallToController(ctx, actionType, send)
 	// fmt.Println("ibc.go upcall reply", reply, err)
 	return reply, err
 }
@@ -197,12 +210,13 @@ func (am AppModule) OnChanOpenInit(
 	counterparty channeltypes.Counterparty,
 	version string,
 ) error {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := channelOpenInitEvent{
@@ -218,12 +232,12 @@ func (am AppModule) OnChanOpenInit(
 		BlockTime:      ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	if err != nil {
 		return err
 	}
@@ -261,12 +275,13 @@ func (am AppModule) OnChanOpenTry(
 	version,
 	counterpartyVersion string,
 ) error {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := channelOpenTryEvent{
@@ -283,12 +298,12 @@ func (am AppModule) OnChanOpenTry(
 		BlockTime:           ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	if err != nil {
 		return err
 	}
@@ -317,12 +332,13 @@ func (am AppModule) OnChanOpenAck(
 	channelID string,
 	counterpartyVersion string,
 ) error {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := channelOpenAckEvent{
@@ -335,12 +351,12 @@ func (am AppModule) OnChanOpenAck(
 		BlockTime:           ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	return err
 }
 
@@ -358,12 +374,13 @@ func (am AppModule) OnChanOpenConfirm(
 	portID,
 	channelID string,
 ) error {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := channelOpenConfirmEvent{
@@ -375,12 +392,12 @@ func (am AppModule) OnChanOpenConfirm(
 		BlockTime:   ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 
 	return err
 }
@@ -399,12 +416,13 @@ func (am AppModule) OnChanCloseInit(
 	portID,
 	channelID string,
 ) error {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := channelCloseInitEvent{
@@ -416,12 +434,12 @@ func (am AppModule) OnChanCloseInit(
 		BlockTime:   ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	return err
 }
 
@@ -439,12 +457,13 @@ func (am AppModule) OnChanCloseConfirm(
 	portID,
 	channelID string,
 ) error {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := channelCloseConfirmEvent{
@@ -456,12 +475,12 @@ func (am AppModule) OnChanCloseConfirm(
 		BlockTime:   ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	return err
 }
 
@@ -477,12 +496,13 @@ func (am AppModule) OnRecvPacket(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 ) (*sdk.Result, []byte, error) {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return &sdk.Result{}, nil, nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	// Sometimes we receive duplicate packets, just with a
@@ -501,13 +521,13 @@ func (am AppModule) OnRecvPacket(
 		BlockTime:   ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// FIXME: Get acknowledgement data from this call.
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -531,12 +551,13 @@ func (am AppModule) OnAcknowledgementPacket(
 	packet channeltypes.Packet,
 	acknowledgement []byte,
 ) (*sdk.Result, error) {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return &sdk.Result{}, nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := acknowledgementPacketEvent{
@@ -548,12 +569,12 @@ func (am AppModule) OnAcknowledgementPacket(
 		BlockTime:       ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	if err != nil {
 		return nil, err
 	}
@@ -575,12 +596,13 @@ func (am AppModule) OnTimeoutPacket(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 ) (*sdk.Result, error) {
-	if committedHeight == ctx.BlockHeight() {
+	if am.controller.committedHeight == ctx.BlockHeight() {
 		// We don't support simulation.
 		return &sdk.Result{}, nil
 	} else {
-		// The simulation was done, so now allow infinite gas.
-		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		// The simulation was done, so charge only storage downcalls to the
+		// transaction's gas.
+		ctx = WithStorageGasMeter(ctx, ctx.GasMeter())
 	}
 
 	event := timeoutPacketEvent{
@@ -591,12 +613,12 @@ func (am AppModule) OnTimeoutPacket(
 		BlockTime:   ctx.BlockTime().Unix(),
 	}
 
-	bytes, err := json.Marshal(&event)
+	bytes, err := am.controller.EncodeAction(&event)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = am.CallToController(ctx, string(bytes))
+	_, err = am.CallToController(ctx, event.Type+"/"+event.Event, bytes)
 	if err != nil {
 		return nil, err
 	}
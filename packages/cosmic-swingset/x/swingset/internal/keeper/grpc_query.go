@@ -2,7 +2,6 @@ package keeper
 
 import (
 	"context"
-	"strings"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -24,7 +23,7 @@ func (k Querier) Storage(c context.Context, req *types.QueryStorageRequest) (*ty
 	}
 	ctx := sdk.UnwrapSDKContext(c)
 
-	storage := k.GetStorage(ctx, strings.Join(req.Path, "."))
+	storage := k.GetStorage(ctx, types.PathString(req.Path))
 
 	return &types.QueryStorageResponse{
 		Value: storage.Value,
@@ -37,10 +36,31 @@ func (k Querier) Keys(c context.Context, req *types.QueryStorageKeysRequest) (*t
 	}
 	ctx := sdk.UnwrapSDKContext(c)
 
-	keys := k.GetKeys(ctx, strings.Join(req.Path, "."))
+	keys, pageRes, err := k.GetKeysPage(ctx, types.PathString(req.Path), req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	return &types.QueryStorageKeysResponse{
-		Keys: keys.Keys,
+		Keys:       keys,
+		Pagination: pageRes,
+	}, nil
+}
+
+func (k Querier) Entries(c context.Context, req *types.QueryStorageEntriesRequest) (*types.QueryStorageEntriesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	entries, pageRes, err := k.GetEntriesPage(ctx, types.PathString(req.Path), req.Pagination)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryStorageEntriesResponse{
+		Entries:    entries,
+		Pagination: pageRes,
 	}, nil
 }
 
@@ -67,3 +87,25 @@ func (k Querier) Mailbox(c context.Context, req *types.QueryMailboxRequest) (*ty
 		Value: mb.Value,
 	}, nil
 }
+
+func (k Querier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryParamsResponse{
+		Params: k.GetParams(ctx),
+	}, nil
+}
+
+func (k Querier) Usage(c context.Context, req *types.QueryStorageUsageRequest) (*types.StorageUsage, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	usage := k.GetStorageUsage(ctx, types.PathString(req.Path))
+
+	return &usage, nil
+}
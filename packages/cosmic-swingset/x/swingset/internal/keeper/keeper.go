@@ -1,16 +1,19 @@
 package keeper
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"sort"
-	"strings"
+	"math/big"
 
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 
@@ -21,14 +24,16 @@ import (
 	porttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/05-port/types"
 	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 	ibcexported "github.com/cosmos/cosmos-sdk/x/ibc/core/exported"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
 	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
 )
 
 // Keeper maintains the link to data storage and exposes getter/setter methods for the various parts of the state machine
 type Keeper struct {
-	storeKey sdk.StoreKey
-	cdc      codec.Marshaler
+	storeKey   sdk.StoreKey
+	cdc        codec.Marshaler
+	paramSpace paramtypes.Subspace
 
 	accountKeeper authkeeper.AccountKeeper
 	bankKeeper    bankkeeper.Keeper
@@ -37,20 +42,27 @@ type Keeper struct {
 	scopedKeeper  capabilitykeeper.ScopedKeeper
 
 	// CallToController dispatches a message to the controlling process
-	CallToController func(ctx sdk.Context, str string) (string, error)
+	// actionType names the action for telemetry.
+	CallToController func(ctx sdk.Context, actionType, str string) (string, error)
 }
 
 // NewKeeper creates a new IBC transfer Keeper instance
 func NewKeeper(
-	cdc codec.Marshaler, key sdk.StoreKey,
+	cdc codec.Marshaler, key sdk.StoreKey, paramSpace paramtypes.Subspace,
 	channelKeeper types.ChannelKeeper, portKeeper types.PortKeeper,
 	accountKeeper authkeeper.AccountKeeper, bankKeeper bankkeeper.Keeper,
 	scopedKeeper capabilitykeeper.ScopedKeeper,
 ) Keeper {
 
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
 	return Keeper{
 		storeKey:      key,
 		cdc:           cdc,
+		paramSpace:    paramSpace,
 		accountKeeper: accountKeeper,
 		bankKeeper:    bankKeeper,
 		channelKeeper: channelKeeper,
@@ -110,93 +122,460 @@ func (k Keeper) SetEgress(ctx sdk.Context, egress *types.Egress) error {
 
 // ExportStorage fetches all storage
 func (k Keeper) ExportStorage(ctx sdk.Context) map[string]string {
+	exported := make(map[string]string)
+	k.IterateStorage(ctx, func(path string, value []byte) bool {
+		exported[path] = string(value)
+		return false
+	})
+	return exported
+}
+
+// CheckKeysIndex checks that the keys index lists exactly the paths that
+// have values, each under its parent.
+func (k Keeper) CheckKeysIndex(ctx sdk.Context) error {
 	store := ctx.KVStore(k.storeKey)
 	dataStore := prefix.NewStore(store, types.DataPrefix)
+	keysStore := prefix.NewStore(store, types.KeysPrefix)
 
-	iterator := sdk.KVStorePrefixIterator(dataStore, nil)
+	keysIterator := sdk.KVStorePrefixIterator(keysStore, nil)
+	defer keysIterator.Close()
+	for ; keysIterator.Valid(); keysIterator.Next() {
+		path, err := types.DecodeDepthKey(keysIterator.Key())
+		if err != nil {
+			return err
+		}
+		if !dataStore.Has(types.EncodePath(path)) {
+			return fmt.Errorf("storage %s is listed, but has no value", path)
+		}
+	}
 
-	exported := make(map[string]string)
+	dataIterator := sdk.KVStorePrefixIterator(dataStore, nil)
+	defer dataIterator.Close()
+	for ; dataIterator.Valid(); dataIterator.Next() {
+		path, err := types.DecodePath(dataIterator.Key())
+		if err != nil {
+			return err
+		}
+		if !keysStore.Has(types.ChildKey(path)) {
+			return fmt.Errorf("storage %s has a value, but is not listed", path)
+		}
+	}
+	return nil
+}
+
+// IterateStorage calls cb with each path and its value, in the order of the
+// store, until cb returns true.
+func (k Keeper) IterateStorage(ctx sdk.Context, cb func(path string, value []byte) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	dataStore := prefix.NewStore(store, types.DataPrefix)
+
+	iterator := sdk.KVStorePrefixIterator(dataStore, nil)
 	defer iterator.Close()
 	for ; iterator.Valid(); iterator.Next() {
-		var storage types.Storage
-		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &storage)
-		exported[string(iterator.Key())] = storage.Value
+		if cb(k.decodeStorageEntry(iterator.Key(), iterator.Value())) {
+			return
+		}
 	}
-	return exported
 }
 
-// GetStorage gets generic storage
+// decodeStorageEntry decodes an entry of DataPrefix, which the keeper
+// wrote, so it panics if the store is corrupt.
+func (k Keeper) decodeStorageEntry(key, bz []byte) (string, []byte) {
+	path, err := types.DecodePath(key)
+	if err != nil {
+		panic(err)
+	}
+	value, err := types.ParseStoreValue(bz)
+	if err != nil {
+		panic(fmt.Errorf("storage %s: %w", path, err))
+	}
+	return path, value
+}
+
+// GetStorage gets generic storage, where an empty value means absent
 func (k Keeper) GetStorage(ctx sdk.Context, path string) *types.Storage {
-	//fmt.Printf("GetStorage(%s)\n", path);
+	value, _ := k.GetStorageBytes(ctx, path)
+	return &types.Storage{Value: string(value)}
+}
+
+// GetStorageBytes gets the value at a path, and whether there is one
+func (k Keeper) GetStorageBytes(ctx sdk.Context, path string) ([]byte, bool) {
+	//fmt.Printf("GetStorageBytes(%s)\n", path);
 	store := ctx.KVStore(k.storeKey)
 	dataStore := prefix.NewStore(store, types.DataPrefix)
-	if !dataStore.Has([]byte(path)) {
-		return &types.Storage{""}
+	key := types.EncodePath(path)
+	if len(key) == 0 {
+		// The root has no value.
+		return nil, false
+	}
+	bz := dataStore.Get(key)
+	if bz == nil {
+		return nil, false
 	}
-	bz := dataStore.Get([]byte(path))
-	var storage types.Storage
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &storage)
-	return &storage
+	_, value := k.decodeStorageEntry(key, bz)
+	return value, true
 }
 
 // GetKeys gets all storage child keys at a given path
 func (k Keeper) GetKeys(ctx sdk.Context, path string) *types.Keys {
 	store := ctx.KVStore(k.storeKey)
-	keysStore := prefix.NewStore(store, types.KeysPrefix)
-	if !keysStore.Has([]byte(path)) {
-		return types.NewKeys()
+	keysStore := prefix.NewStore(store, types.ChildKeysPrefix(path))
+	iterator := sdk.KVStorePrefixIterator(keysStore, nil)
+	defer iterator.Close()
+
+	keys := types.NewKeys()
+	for ; iterator.Valid(); iterator.Next() {
+		key, err := types.DecodeChildKey(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+		keys.Keys = append(keys.Keys, key)
+	}
+	return keys
+}
+
+// GetKeysPage gets one page of the storage child keys at a given path
+func (k Keeper) GetKeysPage(ctx sdk.Context, path string, pageReq *query.PageRequest) ([]string, *query.PageResponse, error) {
+	store := ctx.KVStore(k.storeKey)
+	keysStore := prefix.NewStore(store, types.ChildKeysPrefix(path))
+
+	var keys []string
+	pageRes, err := query.Paginate(keysStore, pageReq, func(childKey []byte, _ []byte) error {
+		key, err := types.DecodeChildKey(childKey)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	return keys, pageRes, err
+}
+
+// GetEntriesPage gets one page of the storage child keys at a given path,
+// together with their values
+func (k Keeper) GetEntriesPage(ctx sdk.Context, path string, pageReq *query.PageRequest) ([]types.StorageEntry, *query.PageResponse, error) {
+	keys, pageRes, err := k.GetKeysPage(ctx, path, pageReq)
+	if err != nil {
+		return nil, nil, err
 	}
-	bz := keysStore.Get([]byte(path))
-	var keys types.Keys
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &keys)
-	return &keys
+	entries := make([]types.StorageEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = types.StorageEntry{
+			Key:   key,
+			Value: k.GetStorage(ctx, types.JoinPath(path, key)).Value,
+		}
+	}
+	return entries, pageRes, nil
 }
 
-// SetStorage sets the entire generic storage for a path
+// SetStorage sets the entire generic storage for a path, where an empty value
+// deletes it
 func (k Keeper) SetStorage(ctx sdk.Context, path string, storage *types.Storage) {
+	if storage.Value == "" {
+		k.DeleteStorage(ctx, path)
+		return
+	}
+	k.SetStorageBytes(ctx, path, []byte(storage.Value))
+}
+
+// SetStorageBytes sets the value at a path, which may be empty
+func (k Keeper) SetStorageBytes(ctx sdk.Context, path string, value []byte) {
+	k.writeStorage(ctx, path, value, true)
+	ctx.EventManager().EmitEvent(newStorageEvent(path, value, true))
+}
+
+// DeleteStorage deletes the value at a path
+func (k Keeper) DeleteStorage(ctx sdk.Context, path string) {
+	k.writeStorage(ctx, path, nil, false)
+	ctx.EventManager().EmitEvent(newStorageEvent(path, nil, false))
+}
+
+// writeStorage sets or deletes the value at a path, together with its entry
+// in the parent's keys and its usage.
+func (k Keeper) writeStorage(ctx sdk.Context, path string, value []byte, present bool) {
 	store := ctx.KVStore(k.storeKey)
 	dataStore := prefix.NewStore(store, types.DataPrefix)
 	keysStore := prefix.NewStore(store, types.KeysPrefix)
 
-	fullPathArray := strings.Split(path, ".")
-	oneUp := strings.Join(fullPathArray[0:len(fullPathArray)-1], ".")
-	lastKey := fullPathArray[len(fullPathArray)-1]
+	oldValue, oldPresent := k.GetStorageBytes(ctx, path)
+	k.updateUsage(ctx, path, storageSize(path, oldValue, oldPresent), storageSize(path, value, present))
 
-	// Get a map corresponding to the keys for the parent key.
-	keyNode := k.GetKeys(ctx, oneUp)
-	keyList := keyNode.Keys
-	keyMap := make(map[string]bool, len(keyList)+1)
-	for _, key := range keyList {
-		keyMap[key] = true
+	if present {
+		dataStore.Set(types.EncodePath(path), types.StoreValue(value))
+		keysStore.Set(types.ChildKey(path), types.ChildKeyValue)
+	} else {
+		dataStore.Delete(types.EncodePath(path))
+		keysStore.Delete(types.ChildKey(path))
 	}
+}
 
-	if storage.Value == "" {
-		delete(keyMap, lastKey)
-	} else {
-		keyMap[lastKey] = true
+// GetParams returns the swingset module parameters.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	// A chain that predates some parameters runs with their defaults.
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
 	}
+	return params
+}
 
-	// Update the list of keys
-	keyList = make([]string, 0, len(keyMap))
-	for key := range keyMap {
-		keyList = append(keyList, key)
+// SetParams sets the swingset module parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// GetStorageUsage gets the storage used at and below a path
+func (k Keeper) GetStorageUsage(ctx sdk.Context, path string) types.StorageUsage {
+	store := ctx.KVStore(k.storeKey)
+	usageStore := prefix.NewStore(store, types.UsagePrefix)
+
+	var usage types.StorageUsage
+	if bz := usageStore.Get(types.UsageKey(path)); bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &usage)
 	}
+	return usage
+}
 
-	// Update the value.
-	if storage.Value == "" {
-		dataStore.Delete([]byte(path))
+func (k Keeper) setStorageUsage(ctx sdk.Context, path string, usage types.StorageUsage) {
+	store := ctx.KVStore(k.storeKey)
+	usageStore := prefix.NewStore(store, types.UsagePrefix)
+
+	if usage.Bytes == 0 && usage.Children == 0 {
+		usageStore.Delete(types.UsageKey(path))
 	} else {
-		dataStore.Set([]byte(path), k.cdc.MustMarshalBinaryLengthPrefixed(storage))
+		usageStore.Set(types.UsageKey(path), k.cdc.MustMarshalBinaryBare(&usage))
 	}
+}
+
+// storageSize is the number of bytes an entry counts against quotas, which is
+// more than zero for any present value, since only the root has an empty path.
+func storageSize(path string, value []byte, present bool) uint64 {
+	if !present {
+		return 0
+	}
+	return uint64(len(path) + len(value))
+}
+
+// updateUsage accounts for replacing an entry of oldSize at path with one of
+// newSize, in the usage of the path and each of its ancestors.
+func (k Keeper) updateUsage(ctx sdk.Context, path string, oldSize, newSize uint64) {
+	if oldSize == newSize {
+		return
+	}
+	parent, _ := types.SplitPath(path)
+	for _, ancestor := range types.PathAncestors(path) {
+		usage := k.GetStorageUsage(ctx, ancestor)
+		usage.Bytes = usage.Bytes - oldSize + newSize
+		if ancestor == parent {
+			switch {
+			case oldSize == 0:
+				usage.Children++
+			case newSize == 0:
+				usage.Children--
+			}
+		}
+		k.setStorageUsage(ctx, ancestor, usage)
+	}
+}
+
+// CheckStorageQuota returns an error if setting the value at path would
+// exceed the limits in the module parameters.
+func (k Keeper) CheckStorageQuota(ctx sdk.Context, path string, value []byte) error {
+	params := k.GetParams(ctx)
+	if params.MaxValueSize > 0 && uint64(len(value)) > params.MaxValueSize {
+		return sdkerrors.Wrapf(types.ErrValueTooLarge,
+			"%s value is %d bytes, limit %d", path, len(value), params.MaxValueSize)
+	}
+
+	oldValue, oldPresent := k.GetStorageBytes(ctx, path)
+	parent, _ := types.SplitPath(path)
+	if !oldPresent && params.MaxChildren > 0 {
+		if children := k.GetStorageUsage(ctx, parent).Children; children >= params.MaxChildren {
+			return sdkerrors.Wrapf(types.ErrTooManyChildren,
+				"%s already has %d children, limit %d", parent, children, params.MaxChildren)
+		}
+	}
+
+	oldSize, newSize := storageSize(path, oldValue, oldPresent), storageSize(path, value, true)
+	if newSize <= oldSize {
+		return nil
+	}
+	for _, quota := range params.QuotasFor(path) {
+		used := k.GetStorageUsage(ctx, quota.Path).Bytes
+		if used-oldSize+newSize > quota.MaxBytes {
+			return sdkerrors.Wrapf(types.ErrStorageQuotaExceeded,
+				"%s would use %d bytes, quota %d", quota.Path, used-oldSize+newSize, quota.MaxBytes)
+		}
+	}
+	return nil
+}
+
+// newStorageEvent describes a write of value to path, or its deletion if
+// not present.
+func newStorageEvent(path string, value []byte, present bool) sdk.Event {
+	if !present {
+		return sdk.NewEvent(
+			types.EventTypeStorage,
+			sdk.NewAttribute(types.AttributeKeyPath, path),
+			sdk.NewAttribute(types.AttributeKeyOperation, types.StorageOperationDelete),
+		)
+	}
+	valueAttr := sdk.NewAttribute(types.AttributeKeyValue, string(value))
+	if len(value) > types.MaxStorageEventValueSize {
+		hash := sha256.Sum256(value)
+		valueAttr = sdk.NewAttribute(types.AttributeKeyValueHash, hex.EncodeToString(hash[:]))
+	}
+	return sdk.NewEvent(
+		types.EventTypeStorage,
+		sdk.NewAttribute(types.AttributeKeyPath, path),
+		sdk.NewAttribute(types.AttributeKeyOperation, types.StorageOperationSet),
+		valueAttr,
+	)
+}
 
-	// Update the keys.
-	if len(keyList) == 0 {
-		keysStore.Delete([]byte(oneUp))
+// CompareAndSetStorage sets the value at a path, or deletes it if not
+// present, only if the path currently has the expected value and presence.
+// It returns whether it did, and the value and presence now at the path.
+func (k Keeper) CompareAndSetStorage(
+	ctx sdk.Context, path string, expected []byte, expectedPresent bool, value []byte, present bool,
+) (bool, []byte, bool) {
+	current, currentPresent := k.GetStorageBytes(ctx, path)
+	if currentPresent != expectedPresent || (currentPresent && !bytes.Equal(current, expected)) {
+		return false, current, currentPresent
+	}
+	if present {
+		k.SetStorageBytes(ctx, path, value)
 	} else {
-		// Update the key node.
-		sort.Strings(keyList)
-		keyNode.Keys = keyList
-		keysStore.Set([]byte(oneUp), k.cdc.MustMarshalBinaryLengthPrefixed(keyNode))
+		k.DeleteStorage(ctx, path)
+	}
+	return true, value, present
+}
+
+// IncrementStorage adds delta to the decimal integer at a path, where absent
+// is zero.  It returns the new value, or an error and the unchanged value and
+// presence if the current value is not an integer.
+func (k Keeper) IncrementStorage(ctx sdk.Context, path string, delta *big.Int) ([]byte, bool, error) {
+	current, present := k.GetStorageBytes(ctx, path)
+	n := new(big.Int)
+	if present {
+		if _, ok := n.SetString(string(current), 10); !ok {
+			return current, present, fmt.Errorf("storage %s value %q is not an integer", path, current)
+		}
+	}
+	next := []byte(n.Add(n, delta).String())
+	k.SetStorageBytes(ctx, path, next)
+	return next, true, nil
+}
+
+// getSubtreePaths gets the paths with data at or below a given path
+func (k Keeper) getSubtreePaths(ctx sdk.Context, path string) []string {
+	store := ctx.KVStore(k.storeKey)
+	dataStore := prefix.NewStore(store, types.DataPrefix)
+
+	var paths []string
+	iterator := sdk.KVStorePrefixIterator(dataStore, types.EncodePath(path))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		p, err := types.DecodePath(iterator.Key())
+		if err != nil {
+			panic(err)
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// DeleteStorageSubtree deletes the storage at and below a path, and returns
+// the number of entries deleted.
+func (k Keeper) DeleteStorageSubtree(ctx sdk.Context, path string) int {
+	paths := k.getSubtreePaths(ctx, path)
+	for _, p := range paths {
+		k.DeleteStorage(ctx, p)
+	}
+	return len(paths)
+}
+
+// MoveStorageSubtree moves the storage at and below one path to another
+// path, which must be empty, and returns the number of entries moved.  It
+// stops at the first entry that would exceed the storage limits, so callers
+// should discard a failed move.
+func (k Keeper) MoveStorageSubtree(ctx sdk.Context, from, to string) (int, error) {
+	fromSegments, toSegments := types.PathSegments(from), types.PathSegments(to)
+	if len(fromSegments) == 0 || len(toSegments) == 0 {
+		return 0, fmt.Errorf("cannot move the storage root")
+	}
+	if hasPathPrefix(toSegments, fromSegments) {
+		return 0, fmt.Errorf("cannot move storage %s into itself at %s", from, to)
+	}
+	if len(k.getSubtreePaths(ctx, to)) > 0 {
+		return 0, fmt.Errorf("storage %s is not empty", to)
+	}
+
+	paths := k.getSubtreePaths(ctx, from)
+	for _, p := range paths {
+		segments := types.PathSegments(p)
+		dest := types.PathString(append(append([]string{}, toSegments...), segments[len(fromSegments):]...))
+		value, _ := k.GetStorageBytes(ctx, p)
+		k.DeleteStorage(ctx, p)
+		if err := k.CheckStorageQuota(ctx, dest, value); err != nil {
+			return 0, err
+		}
+		k.SetStorageBytes(ctx, dest, value)
+	}
+	return len(paths), nil
+}
+
+// hasPathPrefix returns whether the path of segments is at or below the path
+// of prefix.
+func hasPathPrefix(segments, prefix []string) bool {
+	if len(segments) < len(prefix) {
+		return false
+	}
+	for i, segment := range prefix {
+		if segments[i] != segment {
+			return false
+		}
+	}
+	return true
+}
+
+// MigrateStorageLayout moves the legacy storage, which was keyed by
+// "."-separated paths and could not hold empty values, to the current layout,
+// and rebuilds its keys index and usage.  Any legacy keys index or usage is
+// discarded, whichever format it had.
+func (k Keeper) MigrateStorageLayout(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	legacyStore := prefix.NewStore(store, types.LegacyDataPrefix)
+
+	var legacyPaths []string
+	var values [][]byte
+	iterator := sdk.KVStorePrefixIterator(legacyStore, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		var storage types.Storage
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &storage)
+		legacyPaths = append(legacyPaths, string(iterator.Key()))
+		values = append(values, []byte(storage.Value))
+	}
+	iterator.Close()
+
+	for _, legacyPrefix := range [][]byte{types.LegacyDataPrefix, types.LegacyKeysPrefix, types.LegacyUsagePrefix} {
+		deletePrefix(store, legacyPrefix)
+	}
+	for i, legacy := range legacyPaths {
+		k.writeStorage(ctx, types.LegacyPath(legacy), values[i], true)
+	}
+}
+
+// deletePrefix deletes every key in store that starts with keyPrefix.
+func deletePrefix(store sdk.KVStore, keyPrefix []byte) {
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, keyPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
 	}
 }
 
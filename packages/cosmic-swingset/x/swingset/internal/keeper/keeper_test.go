@@ -0,0 +1,521 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	capabilitykeeper "github.com/cosmos/cosmos-sdk/x/capability/keeper"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// newTestKeeper returns a Keeper with storage and parameters, backed by
+// memory.
+func newTestKeeper(t testing.TB) (Keeper, sdk.Context) {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	paramsKey := sdk.NewKVStoreKey(paramtypes.StoreKey)
+	paramsTKey := sdk.NewTransientStoreKey(paramtypes.TStoreKey)
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, nil)
+	if err := cms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	paramSpace := paramtypes.NewSubspace(cdc, codec.NewLegacyAmino(), paramsKey, paramsTKey, types.ModuleName)
+	k := NewKeeper(
+		cdc, key, paramSpace, nil, nil,
+		authkeeper.AccountKeeper{}, nil, capabilitykeeper.ScopedKeeper{},
+	)
+	ctx := sdk.NewContext(cms, tmproto.Header{}, false, log.NewNopLogger())
+	return k, ctx
+}
+
+func TestGetParamsDefaultsUnset(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	if got, want := k.GetParams(ctx), types.DefaultParams(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unset params are %v, want %v", got, want)
+	}
+
+	// As on a chain that has only the parameters that came first.
+	k.paramSpace.Set(ctx, types.KeyMaxValueSize, uint64(100))
+	params := k.GetParams(ctx)
+	if params.MaxValueSize != 100 {
+		t.Errorf("max value size is %d, want 100", params.MaxValueSize)
+	}
+	if params.BlockStorageGasLimit != types.DefaultParams().BlockStorageGasLimit {
+		t.Errorf("unset block gas limit is %d", params.BlockStorageGasLimit)
+	}
+}
+
+func TestMigrateStorageLayout(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	store := ctx.KVStore(k.storeKey)
+
+	// The baseline layout, with a keys list per parent.
+	legacy := map[string]string{"a": "1", "a.b": "2", "c": "3", "c.d@e": "4"}
+	for path, value := range legacy {
+		bz := k.cdc.MustMarshalBinaryLengthPrefixed(&types.Storage{Value: value})
+		store.Set(append(append([]byte{}, types.LegacyDataPrefix...), path...), bz)
+	}
+	keys := k.cdc.MustMarshalBinaryLengthPrefixed(&types.Keys{Keys: []string{"a", "c"}})
+	store.Set(append(append([]byte{}, types.LegacyKeysPrefix...), ""...), keys)
+
+	k.MigrateStorageLayout(ctx)
+
+	for path, value := range legacy {
+		if got, ok := k.GetStorageBytes(ctx, path); !ok || string(got) != value {
+			t.Errorf("%s is %q (present %v), want %q", path, got, ok, value)
+		}
+	}
+	if got := k.GetKeys(ctx, "").Keys; !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("root keys are %q", got)
+	}
+	if got := k.GetKeys(ctx, "a").Keys; !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("keys of a are %q", got)
+	}
+	var bytes uint64
+	for path, value := range legacy {
+		bytes += storageSize(path, []byte(value), true)
+	}
+	if usage := k.GetStorageUsage(ctx, ""); usage.Bytes != bytes || usage.Children != 2 {
+		t.Errorf("root usage is %v, want %d bytes and 2 children", usage, bytes)
+	}
+	for _, legacyPrefix := range [][]byte{types.LegacyDataPrefix, types.LegacyKeysPrefix} {
+		iterator := sdk.KVStorePrefixIterator(store, legacyPrefix)
+		if iterator.Valid() {
+			t.Errorf("legacy key %q remains", iterator.Key())
+		}
+		iterator.Close()
+	}
+}
+
+func TestMoveStorageSubtreeEscapedPaths(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	// "a\.b" is the single segment "a.b", so it is not below "a".
+	for path, value := range map[string]string{`a`: "1", `a.x`: "2", `a\.b`: "3"} {
+		k.SetStorageBytes(ctx, path, []byte(value))
+	}
+
+	if _, err := k.MoveStorageSubtree(ctx, `a`, `a.y`); err == nil {
+		t.Error("moved a into itself")
+	}
+	count, err := k.MoveStorageSubtree(ctx, `a`, `a\.b\.c`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("moved %d entries, want 2", count)
+	}
+	for path, value := range map[string]string{`a\.b\.c`: "1", `a\.b\.c.x`: "2", `a\.b`: "3"} {
+		if got, ok := k.GetStorageBytes(ctx, path); !ok || string(got) != value {
+			t.Errorf("%s is %q (present %v), want %q", path, got, ok, value)
+		}
+	}
+	if _, ok := k.GetStorageBytes(ctx, `a.x`); ok {
+		t.Error("a.x was not moved")
+	}
+}
+
+func TestStorageEvents(t *testing.T) {
+	set := func(path, value string) sdk.Event {
+		return sdk.NewEvent("swingset_storage",
+			sdk.NewAttribute("path", path),
+			sdk.NewAttribute("operation", "set"),
+			sdk.NewAttribute("value", value),
+		)
+	}
+	del := func(path string) sdk.Event {
+		return sdk.NewEvent("swingset_storage",
+			sdk.NewAttribute("path", path),
+			sdk.NewAttribute("operation", "delete"),
+		)
+	}
+	large := strings.Repeat("x", types.MaxStorageEventValueSize+1)
+	largeHash := sha256.Sum256([]byte(large))
+
+	for _, tc := range []struct {
+		name   string
+		write  func(k Keeper, ctx sdk.Context) error
+		events sdk.Events
+	}{
+		{
+			name: "set",
+			write: func(k Keeper, ctx sdk.Context) error {
+				k.SetStorageBytes(ctx, "a.b", []byte("new"))
+				return nil
+			},
+			events: sdk.Events{set("a.b", "new")},
+		},
+		{
+			name: "set empty",
+			write: func(k Keeper, ctx sdk.Context) error {
+				k.SetStorageBytes(ctx, "a.b", []byte{})
+				return nil
+			},
+			events: sdk.Events{set("a.b", "")},
+		},
+		{
+			name: "set large",
+			write: func(k Keeper, ctx sdk.Context) error {
+				k.SetStorageBytes(ctx, "a.b", []byte(large))
+				return nil
+			},
+			events: sdk.Events{sdk.NewEvent("swingset_storage",
+				sdk.NewAttribute("path", "a.b"),
+				sdk.NewAttribute("operation", "set"),
+				sdk.NewAttribute("value_hash", hex.EncodeToString(largeHash[:])),
+			)},
+		},
+		{
+			name: "delete",
+			write: func(k Keeper, ctx sdk.Context) error {
+				k.DeleteStorage(ctx, "a.b")
+				return nil
+			},
+			events: sdk.Events{del("a.b")},
+		},
+		{
+			name: "delete subtree",
+			write: func(k Keeper, ctx sdk.Context) error {
+				if count := k.DeleteStorageSubtree(ctx, "a"); count != 3 {
+					return fmt.Errorf("deleted %d entries", count)
+				}
+				return nil
+			},
+			events: sdk.Events{del("a"), del("a.b"), del("a.c")},
+		},
+		{
+			name: "move subtree",
+			write: func(k Keeper, ctx sdk.Context) error {
+				_, err := k.MoveStorageSubtree(ctx, "a", "m")
+				return err
+			},
+			events: sdk.Events{
+				del("a"), set("m", "1"),
+				del("a.b"), set("m.b", "2"),
+				del("a.c"), set("m.c", "3"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := newTestKeeper(t)
+			for path, value := range map[string]string{"a": "1", "a.b": "2", "a.c": "3", "z": "4"} {
+				k.SetStorageBytes(ctx, path, []byte(value))
+			}
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+			if err := tc.write(k, ctx); err != nil {
+				t.Fatal(err)
+			}
+			if events := ctx.EventManager().Events(); !reflect.DeepEqual(events, tc.events) {
+				t.Errorf("events are %v, want %v", events, tc.events)
+			}
+		})
+	}
+}
+
+// BenchmarkStorageWideParent measures setting and deleting a child of a
+// parent path that already has many children.
+func BenchmarkStorageWideParent(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		k, ctx := newTestKeeper(b)
+		parent := fmt.Sprintf("wide%d", n)
+		value := &types.Storage{Value: "value"}
+		for i := 0; i < n; i++ {
+			k.SetStorage(ctx, fmt.Sprintf("%s.child%d", parent, i), value)
+		}
+		// Forget the storage events of populating the parent.
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		b.Run(fmt.Sprintf("set/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				k.SetStorage(ctx, fmt.Sprintf("%s.set%d", parent, i), value)
+			}
+		})
+		b.Run(fmt.Sprintf("delete/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				path := fmt.Sprintf("%s.delete%d", parent, i)
+				b.StopTimer()
+				k.SetStorage(ctx, path, value)
+				b.StartTimer()
+				k.SetStorage(ctx, path, types.NewStorage())
+			}
+		})
+	}
+}
+
+func TestCheckKeysIndex(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	for _, path := range []string{"a", "a.b", `c\.d`} {
+		k.SetStorageBytes(ctx, path, []byte(path))
+	}
+	if err := k.CheckKeysIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	dangling, _ := ctx.CacheContext()
+	prefix.NewStore(dangling.KVStore(k.storeKey), types.KeysPrefix).Set(types.ChildKey("a.x"), types.ChildKeyValue)
+	if err := k.CheckKeysIndex(dangling); err == nil {
+		t.Error("no error for a dangling child key")
+	}
+
+	missing, _ := ctx.CacheContext()
+	prefix.NewStore(missing.KVStore(k.storeKey), types.KeysPrefix).Delete(types.ChildKey(`c\.d`))
+	if err := k.CheckKeysIndex(missing); err == nil {
+		t.Error("no error for a missing child key")
+	}
+}
+
+func TestQuotasFor(t *testing.T) {
+	params := types.Params{StorageQuotas: []types.StorageQuota{
+		{Path: "", MaxBytes: 1000},
+		{Path: "a", MaxBytes: 100},
+		{Path: "a.b", MaxBytes: 10},
+		{Path: "ab", MaxBytes: 50},
+		{Path: `a\.b`, MaxBytes: 20},
+	}}
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"a", []string{"", "a"}},
+		{"a.b", []string{"", "a", "a.b"}},
+		{"a.b.c", []string{"", "a", "a.b"}},
+		{"a.bc", []string{"", "a"}},
+		{"ab", []string{"", "ab"}},
+		{`a\.b.c`, []string{"", `a\.b`}},
+		{"z", []string{""}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, quota := range params.QuotasFor(tt.path) {
+			got = append(got, quota.Path)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("quotas for %q are %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCheckStorageQuota(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	params := types.DefaultParams()
+	params.MaxValueSize = 10
+	params.MaxChildren = 2
+	// An entry uses the length of its path and value, so "a.b.x" with a
+	// 5-byte value fills the quota on a.b.
+	params.StorageQuotas = []types.StorageQuota{{Path: "a", MaxBytes: 20}, {Path: "a.b", MaxBytes: 10}}
+	k.SetParams(ctx, params)
+	for path, value := range map[string]string{"c.1": "x", "c.2": "x", "a.b.x": "12345"} {
+		k.SetStorageBytes(ctx, path, []byte(value))
+	}
+
+	tests := []struct {
+		path, value string
+		want        error
+		message     string
+	}{
+		{"d", "12345678901", types.ErrValueTooLarge, "d value is 11 bytes, limit 10"},
+		{"d", "1234567890", nil, ""},
+		{"c.3", "x", types.ErrTooManyChildren, "c already has 2 children, limit 2"},
+		{"c.1", "replaced", nil, ""},
+		// Nested quotas: the inner one is exceeded first...
+		{"a.b.x", "123456", types.ErrStorageQuotaExceeded, "a.b would use 11 bytes, quota 10"},
+		{"a.b.x", "54321", nil, ""},
+		{"a.b.x", "1", nil, ""},
+		// ...and the outer one counts what is under the inner one.
+		{"a.y", "12345678", types.ErrStorageQuotaExceeded, "a would use 21 bytes, quota 20"},
+		{"a.y", "1234567", nil, ""},
+	}
+	for _, tt := range tests {
+		err := k.CheckStorageQuota(ctx, tt.path, []byte(tt.value))
+		switch {
+		case tt.want == nil && err != nil:
+			t.Errorf("%s = %q: %v", tt.path, tt.value, err)
+		case tt.want == nil:
+		case !errors.Is(err, tt.want):
+			t.Errorf("%s = %q gave %v, want %v", tt.path, tt.value, err, tt.want)
+		case !strings.Contains(err.Error(), tt.message):
+			t.Errorf("%s = %q gave %q, want %q", tt.path, tt.value, err, tt.message)
+		}
+	}
+}
+
+func TestStorageUsage(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	checkUsage := func(when string, want map[string]types.StorageUsage) {
+		t.Helper()
+		for path, usage := range want {
+			if got := k.GetStorageUsage(ctx, path); got != usage {
+				t.Errorf("%s, usage of %q is %+v, want %+v", when, path, got, usage)
+			}
+		}
+	}
+	// Each entry uses 3 bytes of path and 2 of value.  Only entries with
+	// values count as children, so the root has none.
+	for _, path := range []string{"a.x", "a.y", "b.x"} {
+		k.SetStorageBytes(ctx, path, []byte("12"))
+	}
+	k.SetStorageBytes(ctx, "a.y.z", []byte(""))
+	checkUsage("after set", map[string]types.StorageUsage{
+		"":    {Bytes: 20},
+		"a":   {Bytes: 15, Children: 2},
+		"a.y": {Bytes: 10, Children: 1},
+		"b":   {Bytes: 5, Children: 1},
+	})
+
+	k.SetStorageBytes(ctx, "a.x", []byte("1234"))
+	checkUsage("after replace", map[string]types.StorageUsage{
+		"":  {Bytes: 22},
+		"a": {Bytes: 17, Children: 2},
+	})
+
+	k.DeleteStorage(ctx, "a.y.z")
+	checkUsage("after delete", map[string]types.StorageUsage{
+		"":    {Bytes: 17},
+		"a":   {Bytes: 12, Children: 2},
+		"a.y": {Bytes: 5, Children: 0},
+	})
+
+	if _, err := k.MoveStorageSubtree(ctx, "a", "c"); err != nil {
+		t.Fatal(err)
+	}
+	checkUsage("after move", map[string]types.StorageUsage{
+		"":    {Bytes: 17},
+		"a":   {},
+		"a.x": {},
+		"c":   {Bytes: 12, Children: 2},
+		"c.x": {Bytes: 7},
+		"c.y": {Bytes: 5},
+	})
+
+	if count := k.DeleteStorageSubtree(ctx, "c"); count != 2 {
+		t.Errorf("deleted %d entries, want 2", count)
+	}
+	checkUsage("after delete subtree", map[string]types.StorageUsage{
+		"":  {Bytes: 5},
+		"c": {},
+		"b": {Bytes: 5, Children: 1},
+	})
+}
+
+func TestMoveStorageSubtreeQuota(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	for _, path := range []string{"a.x", "a.y"} {
+		k.SetStorageBytes(ctx, path, []byte("12"))
+	}
+	params := types.DefaultParams()
+	params.StorageQuotas = []types.StorageQuota{{Path: "c", MaxBytes: 9}}
+	k.SetParams(ctx, params)
+
+	moveCtx, _ := ctx.CacheContext()
+	if _, err := k.MoveStorageSubtree(moveCtx, "a", "c"); !errors.Is(err, types.ErrStorageQuotaExceeded) {
+		t.Errorf("moving 10 bytes under a 9-byte quota gave %v", err)
+	}
+	if _, err := k.MoveStorageSubtree(ctx, "a", "b"); err != nil {
+		t.Errorf("moving outside the quota: %v", err)
+	}
+}
+
+func TestGetKeysPage(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	// Only the children of a are listed, in order, and a\.b is not one.
+	for _, path := range []string{"a.k2", "a.k0", "a.k1", "a.k1.x", `a.x\.y`, "a.k3", `a\.b`, "b"} {
+		k.SetStorageBytes(ctx, path, []byte("v"+path))
+	}
+	all := []string{"k0", "k1", "k2", "k3", "x.y"}
+
+	var keys []string
+	pageReq := &query.PageRequest{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > len(all) {
+			t.Fatal("paging did not end")
+		}
+		page, pageRes, err := k.GetKeysPage(ctx, "a", pageReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) > 2 {
+			t.Errorf("page of %d keys, limit 2", len(page))
+		}
+		keys = append(keys, page...)
+		if pageRes.NextKey == nil {
+			break
+		}
+		pageReq = &query.PageRequest{Key: pageRes.NextKey, Limit: 2}
+	}
+	if !reflect.DeepEqual(keys, all) {
+		t.Errorf("paged keys are %q, want %q", keys, all)
+	}
+
+	keys, pageRes, err := k.GetKeysPage(ctx, "a", &query.PageRequest{Offset: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, all[3:]) || pageRes.Total != uint64(len(all)) {
+		t.Errorf("keys from offset 3 are %q of %d", keys, pageRes.Total)
+	}
+
+	keys, _, err = k.GetKeysPage(ctx, "a", &query.PageRequest{Key: types.ChildKeyAfter("k1"), Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, all[2:]) {
+		t.Errorf("keys after k1 are %q", keys)
+	}
+
+	keys, pageRes, err = k.GetKeysPage(ctx, "none", nil)
+	if err != nil || keys != nil || pageRes.NextKey != nil {
+		t.Errorf("keys of an empty path are %q, %v, %v", keys, pageRes, err)
+	}
+}
+
+func TestGetEntriesPage(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	for _, path := range []string{"a.k0", "a.k1", "a.k1.x", "a.k2"} {
+		k.SetStorageBytes(ctx, path, []byte("v"+path))
+	}
+
+	entries, pageRes, err := k.GetEntriesPage(ctx, "a", &query.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.StorageEntry{{Key: "k0", Value: "va.k0"}, {Key: "k1", Value: "va.k1"}}
+	if !reflect.DeepEqual(entries, want) || pageRes.NextKey == nil {
+		t.Errorf("first page is %v, next %q", entries, pageRes.NextKey)
+	}
+
+	entries, pageRes, err = k.GetEntriesPage(ctx, "a", &query.PageRequest{Key: pageRes.NextKey, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []types.StorageEntry{{Key: "k2", Value: "va.k2"}}
+	if !reflect.DeepEqual(entries, want) || pageRes.NextKey != nil {
+		t.Errorf("last page is %v, next %q", entries, pageRes.NextKey)
+	}
+
+	if _, _, err := k.GetEntriesPage(ctx, "a", &query.PageRequest{Key: []byte("k"), Offset: 1}); err == nil {
+		t.Error("no error for both a key and an offset")
+	}
+}
@@ -0,0 +1,4562 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: agoric/swingset/controller.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ControllerAction is the binary encoding of an upcall to the SwingSet
+// controller.  AG_COSMOS_INIT is always sent as JSON, since it negotiates the
+// encoding.
+type ControllerAction struct {
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type"`
+	BlockHeight int64  `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"blockHeight"`
+	BlockTime   int64  `protobuf:"varint,3,opt,name=block_time,json=blockTime,proto3" json:"blockTime"`
+	// Types that are valid to be assigned to Body:
+	//	*ControllerAction_BeginBlock
+	//	*ControllerAction_EndBlock
+	//	*ControllerAction_DeliverInbound
+	//	*ControllerAction_Provision
+	//	*ControllerAction_IbcEvent
+	//	*ControllerAction_ExportKernel
+	//	*ControllerAction_ImportKernel
+	Body isControllerAction_Body `protobuf_oneof:"body"`
+}
+
+func (m *ControllerAction) Reset()         { *m = ControllerAction{} }
+func (m *ControllerAction) String() string { return proto.CompactTextString(m) }
+func (*ControllerAction) ProtoMessage()    {}
+func (*ControllerAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{0}
+}
+func (m *ControllerAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ControllerAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ControllerAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ControllerAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ControllerAction.Merge(m, src)
+}
+func (m *ControllerAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *ControllerAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ControllerAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ControllerAction proto.InternalMessageInfo
+
+type isControllerAction_Body interface {
+	isControllerAction_Body()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type ControllerAction_BeginBlock struct {
+	BeginBlock *BeginBlockAction `protobuf:"bytes,10,opt,name=begin_block,json=beginBlock,proto3,oneof" json:"begin_block,omitempty"`
+}
+type ControllerAction_EndBlock struct {
+	EndBlock *EndBlockAction `protobuf:"bytes,11,opt,name=end_block,json=endBlock,proto3,oneof" json:"end_block,omitempty"`
+}
+type ControllerAction_DeliverInbound struct {
+	DeliverInbound *DeliverInboundAction `protobuf:"bytes,12,opt,name=deliver_inbound,json=deliverInbound,proto3,oneof" json:"deliver_inbound,omitempty"`
+}
+type ControllerAction_Provision struct {
+	Provision *ProvisionAction `protobuf:"bytes,13,opt,name=provision,proto3,oneof" json:"provision,omitempty"`
+}
+type ControllerAction_IbcEvent struct {
+	IbcEvent *IBCEvent `protobuf:"bytes,14,opt,name=ibc_event,json=ibcEvent,proto3,oneof" json:"ibc_event,omitempty"`
+}
+type ControllerAction_ExportKernel struct {
+	ExportKernel *ExportKernelAction `protobuf:"bytes,15,opt,name=export_kernel,json=exportKernel,proto3,oneof" json:"export_kernel,omitempty"`
+}
+type ControllerAction_ImportKernel struct {
+	ImportKernel *ImportKernelAction `protobuf:"bytes,16,opt,name=import_kernel,json=importKernel,proto3,oneof" json:"import_kernel,omitempty"`
+}
+
+func (*ControllerAction_BeginBlock) isControllerAction_Body()     {}
+func (*ControllerAction_EndBlock) isControllerAction_Body()       {}
+func (*ControllerAction_DeliverInbound) isControllerAction_Body() {}
+func (*ControllerAction_Provision) isControllerAction_Body()      {}
+func (*ControllerAction_IbcEvent) isControllerAction_Body()       {}
+func (*ControllerAction_ExportKernel) isControllerAction_Body()   {}
+func (*ControllerAction_ImportKernel) isControllerAction_Body()   {}
+
+func (m *ControllerAction) GetBody() isControllerAction_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ControllerAction) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ControllerAction) GetBlockTime() int64 {
+	if m != nil {
+		return m.BlockTime
+	}
+	return 0
+}
+
+func (m *ControllerAction) GetBeginBlock() *BeginBlockAction {
+	if x, ok := m.GetBody().(*ControllerAction_BeginBlock); ok {
+		return x.BeginBlock
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetEndBlock() *EndBlockAction {
+	if x, ok := m.GetBody().(*ControllerAction_EndBlock); ok {
+		return x.EndBlock
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetDeliverInbound() *DeliverInboundAction {
+	if x, ok := m.GetBody().(*ControllerAction_DeliverInbound); ok {
+		return x.DeliverInbound
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetProvision() *ProvisionAction {
+	if x, ok := m.GetBody().(*ControllerAction_Provision); ok {
+		return x.Provision
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetIbcEvent() *IBCEvent {
+	if x, ok := m.GetBody().(*ControllerAction_IbcEvent); ok {
+		return x.IbcEvent
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetExportKernel() *ExportKernelAction {
+	if x, ok := m.GetBody().(*ControllerAction_ExportKernel); ok {
+		return x.ExportKernel
+	}
+	return nil
+}
+
+func (m *ControllerAction) GetImportKernel() *ImportKernelAction {
+	if x, ok := m.GetBody().(*ControllerAction_ImportKernel); ok {
+		return x.ImportKernel
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ControllerAction) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ControllerAction_BeginBlock)(nil),
+		(*ControllerAction_EndBlock)(nil),
+		(*ControllerAction_DeliverInbound)(nil),
+		(*ControllerAction_Provision)(nil),
+		(*ControllerAction_IbcEvent)(nil),
+		(*ControllerAction_ExportKernel)(nil),
+		(*ControllerAction_ImportKernel)(nil),
+	}
+}
+
+// BeginBlockAction is the body of BEGIN_BLOCK.
+type BeginBlockAction struct {
+	StoragePort int32  `protobuf:"varint,1,opt,name=storage_port,json=storagePort,proto3" json:"storagePort"`
+	ChainID     string `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chainID"`
+}
+
+func (m *BeginBlockAction) Reset()         { *m = BeginBlockAction{} }
+func (m *BeginBlockAction) String() string { return proto.CompactTextString(m) }
+func (*BeginBlockAction) ProtoMessage()    {}
+func (*BeginBlockAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{1}
+}
+func (m *BeginBlockAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BeginBlockAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BeginBlockAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BeginBlockAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeginBlockAction.Merge(m, src)
+}
+func (m *BeginBlockAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *BeginBlockAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeginBlockAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeginBlockAction proto.InternalMessageInfo
+
+func (m *BeginBlockAction) GetStoragePort() int32 {
+	if m != nil {
+		return m.StoragePort
+	}
+	return 0
+}
+
+func (m *BeginBlockAction) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
+// EndBlockAction is the body of END_BLOCK.
+type EndBlockAction struct {
+	StoragePort int32 `protobuf:"varint,1,opt,name=storage_port,json=storagePort,proto3" json:"storagePort"`
+}
+
+func (m *EndBlockAction) Reset()         { *m = EndBlockAction{} }
+func (m *EndBlockAction) String() string { return proto.CompactTextString(m) }
+func (*EndBlockAction) ProtoMessage()    {}
+func (*EndBlockAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{2}
+}
+func (m *EndBlockAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EndBlockAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EndBlockAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EndBlockAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndBlockAction.Merge(m, src)
+}
+func (m *EndBlockAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *EndBlockAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndBlockAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndBlockAction proto.InternalMessageInfo
+
+func (m *EndBlockAction) GetStoragePort() int32 {
+	if m != nil {
+		return m.StoragePort
+	}
+	return 0
+}
+
+// ExportKernelAction is the body of EXPORT_KERNEL.
+type ExportKernelAction struct {
+	StoragePort int32 `protobuf:"varint,1,opt,name=storage_port,json=storagePort,proto3" json:"storagePort"`
+}
+
+func (m *ExportKernelAction) Reset()         { *m = ExportKernelAction{} }
+func (m *ExportKernelAction) String() string { return proto.CompactTextString(m) }
+func (*ExportKernelAction) ProtoMessage()    {}
+func (*ExportKernelAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{3}
+}
+func (m *ExportKernelAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportKernelAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportKernelAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportKernelAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportKernelAction.Merge(m, src)
+}
+func (m *ExportKernelAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportKernelAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportKernelAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportKernelAction proto.InternalMessageInfo
+
+func (m *ExportKernelAction) GetStoragePort() int32 {
+	if m != nil {
+		return m.StoragePort
+	}
+	return 0
+}
+
+// ImportKernelAction is the body of IMPORT_KERNEL.
+type ImportKernelAction struct {
+	StoragePort  int32  `protobuf:"varint,1,opt,name=storage_port,json=storagePort,proto3" json:"storagePort"`
+	ChainID      string `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chainID"`
+	ExportHeight int64  `protobuf:"varint,3,opt,name=export_height,json=exportHeight,proto3" json:"exportHeight"`
+	Hash         string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash"`
+	Bundle       []byte `protobuf:"bytes,5,opt,name=bundle,proto3" json:"bundle"`
+}
+
+func (m *ImportKernelAction) Reset()         { *m = ImportKernelAction{} }
+func (m *ImportKernelAction) String() string { return proto.CompactTextString(m) }
+func (*ImportKernelAction) ProtoMessage()    {}
+func (*ImportKernelAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{4}
+}
+func (m *ImportKernelAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportKernelAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportKernelAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ImportKernelAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportKernelAction.Merge(m, src)
+}
+func (m *ImportKernelAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportKernelAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportKernelAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportKernelAction proto.InternalMessageInfo
+
+func (m *ImportKernelAction) GetStoragePort() int32 {
+	if m != nil {
+		return m.StoragePort
+	}
+	return 0
+}
+
+func (m *ImportKernelAction) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
+func (m *ImportKernelAction) GetExportHeight() int64 {
+	if m != nil {
+		return m.ExportHeight
+	}
+	return 0
+}
+
+func (m *ImportKernelAction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *ImportKernelAction) GetBundle() []byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// InboundMessage is one numbered mailbox message.
+type InboundMessage struct {
+	Num  uint64 `protobuf:"varint,1,opt,name=num,proto3" json:"num"`
+	Body string `protobuf:"bytes,2,opt,name=body,proto3" json:"body"`
+}
+
+func (m *InboundMessage) Reset()         { *m = InboundMessage{} }
+func (m *InboundMessage) String() string { return proto.CompactTextString(m) }
+func (*InboundMessage) ProtoMessage()    {}
+func (*InboundMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{5}
+}
+func (m *InboundMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InboundMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InboundMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InboundMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InboundMessage.Merge(m, src)
+}
+func (m *InboundMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *InboundMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_InboundMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InboundMessage proto.InternalMessageInfo
+
+func (m *InboundMessage) GetNum() uint64 {
+	if m != nil {
+		return m.Num
+	}
+	return 0
+}
+
+func (m *InboundMessage) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+// DeliverInboundAction is the body of DELIVER_INBOUND.
+type DeliverInboundAction struct {
+	Peer        string           `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer"`
+	Messages    []InboundMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages"`
+	Ack         uint64           `protobuf:"varint,3,opt,name=ack,proto3" json:"ack"`
+	StoragePort int32            `protobuf:"varint,4,opt,name=storage_port,json=storagePort,proto3" json:"storagePort"`
+}
+
+func (m *DeliverInboundAction) Reset()         { *m = DeliverInboundAction{} }
+func (m *DeliverInboundAction) String() string { return proto.CompactTextString(m) }
+func (*DeliverInboundAction) ProtoMessage()    {}
+func (*DeliverInboundAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{6}
+}
+func (m *DeliverInboundAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeliverInboundAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeliverInboundAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeliverInboundAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverInboundAction.Merge(m, src)
+}
+func (m *DeliverInboundAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeliverInboundAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverInboundAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverInboundAction proto.InternalMessageInfo
+
+func (m *DeliverInboundAction) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *DeliverInboundAction) GetMessages() []InboundMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *DeliverInboundAction) GetAck() uint64 {
+	if m != nil {
+		return m.Ack
+	}
+	return 0
+}
+
+func (m *DeliverInboundAction) GetStoragePort() int32 {
+	if m != nil {
+		return m.StoragePort
+	}
+	return 0
+}
+
+// ProvisionAction is the body of PLEASE_PROVISION.
+type ProvisionAction struct {
+	Nickname   string                                        `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname"`
+	Address    github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,2,opt,name=address,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"address"`
+	PowerFlags []string                                      `protobuf:"bytes,3,rep,name=power_flags,json=powerFlags,proto3" json:"powerFlags"`
+	Submitter  github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,4,opt,name=submitter,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"submitter"`
+}
+
+func (m *ProvisionAction) Reset()         { *m = ProvisionAction{} }
+func (m *ProvisionAction) String() string { return proto.CompactTextString(m) }
+func (*ProvisionAction) ProtoMessage()    {}
+func (*ProvisionAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{7}
+}
+func (m *ProvisionAction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProvisionAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProvisionAction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProvisionAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProvisionAction.Merge(m, src)
+}
+func (m *ProvisionAction) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProvisionAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProvisionAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProvisionAction proto.InternalMessageInfo
+
+func (m *ProvisionAction) GetNickname() string {
+	if m != nil {
+		return m.Nickname
+	}
+	return ""
+}
+
+func (m *ProvisionAction) GetAddress() github_com_cosmos_cosmos_sdk_types.AccAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *ProvisionAction) GetPowerFlags() []string {
+	if m != nil {
+		return m.PowerFlags
+	}
+	return nil
+}
+
+func (m *ProvisionAction) GetSubmitter() github_com_cosmos_cosmos_sdk_types.AccAddress {
+	if m != nil {
+		return m.Submitter
+	}
+	return nil
+}
+
+// IBCEvent is the body of every IBC_EVENT.  Only the fields that belong to the
+// event are set.
+type IBCEvent struct {
+	Event               string                                        `protobuf:"bytes,1,opt,name=event,proto3" json:"event"`
+	Packet              types.Packet                                  `protobuf:"bytes,2,opt,name=packet,proto3" json:"packet"`
+	Acknowledgement     []byte                                        `protobuf:"bytes,3,opt,name=acknowledgement,proto3" json:"acknowledgement"`
+	Order               string                                        `protobuf:"bytes,4,opt,name=order,proto3" json:"order"`
+	ConnectionHops      []string                                      `protobuf:"bytes,5,rep,name=connection_hops,json=connectionHops,proto3" json:"connectionHops"`
+	PortID              string                                        `protobuf:"bytes,6,opt,name=port_id,json=portId,proto3" json:"portID"`
+	ChannelID           string                                        `protobuf:"bytes,7,opt,name=channel_id,json=channelId,proto3" json:"channelID"`
+	Counterparty        types.Counterparty                            `protobuf:"bytes,8,opt,name=counterparty,proto3" json:"counterparty"`
+	Version             string                                        `protobuf:"bytes,9,opt,name=version,proto3" json:"version"`
+	CounterpartyVersion string                                        `protobuf:"bytes,10,opt,name=counterparty_version,json=counterpartyVersion,proto3" json:"counterpartyVersion"`
+	Submitter           github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,11,opt,name=submitter,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"submitter"`
+}
+
+func (m *IBCEvent) Reset()         { *m = IBCEvent{} }
+func (m *IBCEvent) String() string { return proto.CompactTextString(m) }
+func (*IBCEvent) ProtoMessage()    {}
+func (*IBCEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{8}
+}
+func (m *IBCEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IBCEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IBCEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IBCEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IBCEvent.Merge(m, src)
+}
+func (m *IBCEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *IBCEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_IBCEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IBCEvent proto.InternalMessageInfo
+
+func (m *IBCEvent) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *IBCEvent) GetPacket() types.Packet {
+	if m != nil {
+		return m.Packet
+	}
+	return types.Packet{}
+}
+
+func (m *IBCEvent) GetAcknowledgement() []byte {
+	if m != nil {
+		return m.Acknowledgement
+	}
+	return nil
+}
+
+func (m *IBCEvent) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+func (m *IBCEvent) GetConnectionHops() []string {
+	if m != nil {
+		return m.ConnectionHops
+	}
+	return nil
+}
+
+func (m *IBCEvent) GetPortID() string {
+	if m != nil {
+		return m.PortID
+	}
+	return ""
+}
+
+func (m *IBCEvent) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *IBCEvent) GetCounterparty() types.Counterparty {
+	if m != nil {
+		return m.Counterparty
+	}
+	return types.Counterparty{}
+}
+
+func (m *IBCEvent) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *IBCEvent) GetCounterpartyVersion() string {
+	if m != nil {
+		return m.CounterpartyVersion
+	}
+	return ""
+}
+
+func (m *IBCEvent) GetSubmitter() github_com_cosmos_cosmos_sdk_types.AccAddress {
+	if m != nil {
+		return m.Submitter
+	}
+	return nil
+}
+
+// StorageMessage is the binary encoding of a downcall to the "storage" port.
+type StorageMessage struct {
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key"`
+	Value  string `protobuf:"bytes,3,opt,name=value,proto3" json:"value"`
+	// Entries are the keys and values of setMany.
+	Entries []StorageEntry `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries"`
+	// Keys are the keys of getMany and deleteMany.
+	Keys []string `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys"`
+	// Expected is the current value required by setIfEqual, or unset if the
+	// key must be absent.
+	Expected *Storage `protobuf:"bytes,7,opt,name=expected,proto3" json:"expected"`
+	// After is the child key that keys, entries and values list after, or
+	// empty to list from the first.
+	After string `protobuf:"bytes,8,opt,name=after,proto3" json:"after"`
+	// Limit is the most children that keys, entries and values list, or zero
+	// for all of them up to a fixed maximum.
+	Limit uint64 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit"`
+}
+
+func (m *StorageMessage) Reset()         { *m = StorageMessage{} }
+func (m *StorageMessage) String() string { return proto.CompactTextString(m) }
+func (*StorageMessage) ProtoMessage()    {}
+func (*StorageMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{9}
+}
+func (m *StorageMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageMessage.Merge(m, src)
+}
+func (m *StorageMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageMessage proto.InternalMessageInfo
+
+func (m *StorageMessage) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *StorageMessage) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StorageMessage) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *StorageMessage) GetEntries() []StorageEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *StorageMessage) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *StorageMessage) GetExpected() *Storage {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *StorageMessage) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *StorageMessage) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// ChannelMessage is the binary encoding of a downcall to the "dibc" port.
+type ChannelMessage struct {
+	Type            string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type"`
+	Method          string       `protobuf:"bytes,2,opt,name=method,proto3" json:"method"`
+	Packet          types.Packet `protobuf:"bytes,3,opt,name=packet,proto3" json:"packet"`
+	RelativeTimeout uint64       `protobuf:"varint,4,opt,name=relative_timeout,json=relativeTimeout,proto3" json:"relativeTimeout"`
+	Order           string       `protobuf:"bytes,5,opt,name=order,proto3" json:"order"`
+	Hops            []string     `protobuf:"bytes,6,rep,name=hops,proto3" json:"hops"`
+	Version         string       `protobuf:"bytes,7,opt,name=version,proto3" json:"version"`
+	Ack             []byte       `protobuf:"bytes,8,opt,name=ack,proto3" json:"ack"`
+}
+
+func (m *ChannelMessage) Reset()         { *m = ChannelMessage{} }
+func (m *ChannelMessage) String() string { return proto.CompactTextString(m) }
+func (*ChannelMessage) ProtoMessage()    {}
+func (*ChannelMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6df1a9bb7353b8b, []int{10}
+}
+func (m *ChannelMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChannelMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelMessage.Merge(m, src)
+}
+func (m *ChannelMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelMessage proto.InternalMessageInfo
+
+func (m *ChannelMessage) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ChannelMessage) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ChannelMessage) GetPacket() types.Packet {
+	if m != nil {
+		return m.Packet
+	}
+	return types.Packet{}
+}
+
+func (m *ChannelMessage) GetRelativeTimeout() uint64 {
+	if m != nil {
+		return m.RelativeTimeout
+	}
+	return 0
+}
+
+func (m *ChannelMessage) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+func (m *ChannelMessage) GetHops() []string {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+func (m *ChannelMessage) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChannelMessage) GetAck() []byte {
+	if m != nil {
+		return m.Ack
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ControllerAction)(nil), "agoric.swingset.ControllerAction")
+	proto.RegisterType((*BeginBlockAction)(nil), "agoric.swingset.BeginBlockAction")
+	proto.RegisterType((*EndBlockAction)(nil), "agoric.swingset.EndBlockAction")
+	proto.RegisterType((*ExportKernelAction)(nil), "agoric.swingset.ExportKernelAction")
+	proto.RegisterType((*ImportKernelAction)(nil), "agoric.swingset.ImportKernelAction")
+	proto.RegisterType((*InboundMessage)(nil), "agoric.swingset.InboundMessage")
+	proto.RegisterType((*DeliverInboundAction)(nil), "agoric.swingset.DeliverInboundAction")
+	proto.RegisterType((*ProvisionAction)(nil), "agoric.swingset.ProvisionAction")
+	proto.RegisterType((*IBCEvent)(nil), "agoric.swingset.IBCEvent")
+	proto.RegisterType((*StorageMessage)(nil), "agoric.swingset.StorageMessage")
+	proto.RegisterType((*ChannelMessage)(nil), "agoric.swingset.ChannelMessage")
+}
+
+func init() { proto.RegisterFile("agoric/swingset/controller.proto", fileDescriptor_e6df1a9bb7353b8b) }
+
+var fileDescriptor_e6df1a9bb7353b8b = []byte{
+	// 1357 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x13, 0xb7, 0xbe, 0xa5, 0x95, 0x22, 0x09, 0x1b, 0xe3, 0xff, 0x67, 0xd2, 0xc4, 0x54, 0x5c, 0x04,
+	0xf0, 0xa1, 0x96, 0x10, 0x17, 0x05, 0xda, 0xa6, 0x09, 0x6a, 0x3a, 0x29, 0x6c, 0x17, 0x69, 0x8d,
+	0x6d, 0x9a, 0x43, 0x5b, 0x40, 0xa0, 0x96, 0x1b, 0x69, 0x21, 0x91, 0x2b, 0x90, 0x94, 0x12, 0x9f,
+	0xfa, 0x02, 0x3d, 0xf4, 0x11, 0x7a, 0xef, 0xbd, 0xcf, 0x90, 0x63, 0x8e, 0x39, 0x11, 0x85, 0x72,
+	0x29, 0xf8, 0x08, 0x39, 0x15, 0x3b, 0xbb, 0x24, 0xf5, 0x61, 0x07, 0x6d, 0xda, 0x5e, 0x76, 0x67,
+	0x7e, 0x3b, 0x33, 0xdc, 0x1d, 0xce, 0xfc, 0x96, 0x44, 0x1d, 0x7b, 0x28, 0x7c, 0x4e, 0x7b, 0xc1,
+	0x33, 0xee, 0x0d, 0x03, 0x16, 0xf6, 0xa8, 0xf0, 0x42, 0x5f, 0x4c, 0x26, 0xcc, 0xef, 0x4e, 0x7d,
+	0x11, 0x0a, 0xdc, 0x52, 0x16, 0xdd, 0xc4, 0xe2, 0xfa, 0xf6, 0x50, 0x0c, 0x05, 0xac, 0xf5, 0xa4,
+	0xa4, 0xcc, 0xae, 0xdf, 0x5c, 0x0f, 0x14, 0x84, 0xc2, 0xb7, 0x87, 0x4c, 0x2f, 0xdf, 0xe2, 0x03,
+	0xda, 0xa3, 0xc2, 0x67, 0x3d, 0x3a, 0xb2, 0x3d, 0x8f, 0x4d, 0x7a, 0xf3, 0x3b, 0x89, 0xa8, 0x4c,
+	0x76, 0x7f, 0x2a, 0xa1, 0xf6, 0x51, 0xfa, 0xf4, 0x43, 0x1a, 0x72, 0xe1, 0xe1, 0x1b, 0xa8, 0x18,
+	0x9e, 0x4f, 0x99, 0x91, 0xeb, 0xe4, 0xf6, 0x6a, 0x56, 0x35, 0x8e, 0x4c, 0xd0, 0x09, 0x8c, 0xf8,
+	0x00, 0x35, 0x06, 0x13, 0x41, 0xc7, 0xfd, 0x11, 0xe3, 0xc3, 0x51, 0x68, 0xe4, 0x3b, 0xb9, 0xbd,
+	0x82, 0xd5, 0x8a, 0x23, 0xb3, 0x0e, 0xf8, 0x31, 0xc0, 0x64, 0x59, 0xc1, 0x1f, 0x20, 0xa4, 0x7c,
+	0x42, 0xee, 0x32, 0xa3, 0x00, 0x1e, 0x57, 0xe2, 0xc8, 0xac, 0x01, 0xfa, 0x98, 0xbb, 0x8c, 0x64,
+	0x22, 0x7e, 0x80, 0xea, 0x03, 0x36, 0xe4, 0x5e, 0x1f, 0x20, 0x03, 0x75, 0x72, 0x7b, 0xf5, 0x83,
+	0x5b, 0xdd, 0xb5, 0x9c, 0x74, 0x2d, 0x69, 0x63, 0x49, 0x13, 0xb5, 0xef, 0xe3, 0x2d, 0x82, 0x06,
+	0x29, 0x86, 0xef, 0xa3, 0x1a, 0xf3, 0x1c, 0x1d, 0xa3, 0x0e, 0x31, 0xcc, 0x8d, 0x18, 0x0f, 0x3d,
+	0x67, 0x35, 0x42, 0x95, 0x69, 0x04, 0x9f, 0xa1, 0x96, 0xc3, 0x26, 0x7c, 0xce, 0xfc, 0x3e, 0xf7,
+	0x06, 0x62, 0xe6, 0x39, 0x46, 0x03, 0xa2, 0xdc, 0xde, 0x88, 0xf2, 0x40, 0xd9, 0x9d, 0x28, 0xb3,
+	0x34, 0x56, 0xd3, 0x59, 0xc1, 0xf1, 0xe7, 0xa8, 0x36, 0xf5, 0xc5, 0x9c, 0x07, 0x5c, 0x78, 0xc6,
+	0x15, 0x88, 0xd5, 0xd9, 0x88, 0x75, 0x96, 0x58, 0xa4, 0x61, 0x32, 0x27, 0xfc, 0x31, 0xaa, 0xf1,
+	0x01, 0xed, 0xb3, 0x39, 0xf3, 0x42, 0xa3, 0x09, 0x11, 0xae, 0x6d, 0x44, 0x38, 0xb1, 0x8e, 0x1e,
+	0x4a, 0x03, 0x79, 0x1a, 0x3e, 0xa0, 0x20, 0xe3, 0x53, 0x74, 0x85, 0x3d, 0x9f, 0x0a, 0x3f, 0xec,
+	0x8f, 0x99, 0xef, 0xb1, 0x89, 0xd1, 0x02, 0xef, 0xf7, 0x37, 0x33, 0x02, 0x56, 0x5f, 0x82, 0x51,
+	0xba, 0x85, 0x06, 0x5b, 0x42, 0x65, 0x2c, 0xee, 0x2e, 0xc7, 0x6a, 0x5f, 0x12, 0xeb, 0xc4, 0xbd,
+	0x28, 0x16, 0x5f, 0x42, 0x3f, 0x2d, 0xfe, 0xf1, 0x8b, 0xb9, 0x65, 0x95, 0x51, 0x71, 0x20, 0x9c,
+	0xf3, 0xdd, 0x1f, 0x51, 0x7b, 0xfd, 0xad, 0xca, 0x7a, 0xd3, 0x65, 0xdd, 0x97, 0x7e, 0x50, 0x95,
+	0x25, 0x55, 0x6f, 0x1a, 0x3f, 0x13, 0x7e, 0x48, 0x96, 0x15, 0x7c, 0x07, 0x55, 0xe9, 0xc8, 0xe6,
+	0x5e, 0x9f, 0x3b, 0x50, 0x9f, 0x35, 0xeb, 0x7f, 0x8b, 0xc8, 0xac, 0x1c, 0x49, 0xec, 0xe4, 0x41,
+	0x1c, 0x99, 0x15, 0xaa, 0x44, 0xa2, 0x05, 0x47, 0x6d, 0x64, 0xf7, 0x14, 0x35, 0x57, 0x4b, 0xe2,
+	0x5d, 0x1e, 0xaf, 0x63, 0x7d, 0x85, 0xf0, 0x66, 0x32, 0xff, 0x41, 0xbc, 0x37, 0x39, 0x84, 0x4f,
+	0xdc, 0x7f, 0x23, 0xe0, 0x3b, 0xe4, 0x07, 0x7f, 0x94, 0x16, 0x90, 0xee, 0x7b, 0xd5, 0xc5, 0xed,
+	0x38, 0x32, 0x75, 0x75, 0xe8, 0xc6, 0x5f, 0xd1, 0x24, 0x97, 0x8c, 0xec, 0x60, 0x64, 0x14, 0x33,
+	0x2e, 0x91, 0x3a, 0x81, 0x11, 0xef, 0xa2, 0xf2, 0x60, 0xe6, 0x39, 0x13, 0x66, 0x94, 0x3a, 0xb9,
+	0xbd, 0x86, 0x85, 0xe2, 0xc8, 0xd4, 0x08, 0xd1, 0xb3, 0x3e, 0xfc, 0xd7, 0xa8, 0xa9, 0xdb, 0xe8,
+	0x11, 0x0b, 0x02, 0x7b, 0xc8, 0xf0, 0x35, 0x54, 0xf0, 0x66, 0x2e, 0x1c, 0xb7, 0x68, 0x55, 0xe2,
+	0xc8, 0x94, 0x2a, 0x91, 0x03, 0xbe, 0xa1, 0xca, 0xc9, 0xc8, 0x67, 0x0f, 0x95, 0x3a, 0x81, 0x51,
+	0x07, 0x7c, 0x95, 0x43, 0xdb, 0x17, 0xf5, 0xad, 0x74, 0x9e, 0x32, 0xe6, 0x2f, 0xb3, 0x9f, 0xd4,
+	0x09, 0x8c, 0xf8, 0x11, 0xaa, 0xba, 0x6a, 0x03, 0x81, 0x91, 0xef, 0x14, 0x2e, 0x24, 0x95, 0xd5,
+	0x8d, 0x5a, 0xed, 0x17, 0x91, 0xb9, 0x15, 0x47, 0x66, 0xea, 0x48, 0x52, 0x49, 0x1e, 0xc2, 0xa6,
+	0x63, 0xa3, 0x90, 0x1d, 0xc2, 0xa6, 0x63, 0x22, 0x87, 0x8d, 0xf7, 0x5a, 0xfc, 0xcb, 0x85, 0xf2,
+	0x5b, 0x1e, 0xb5, 0xd6, 0x68, 0x04, 0xef, 0xa1, 0xaa, 0xc7, 0xe9, 0xd8, 0xb3, 0xdd, 0x84, 0xd7,
+	0x1b, 0x72, 0x4b, 0x09, 0x46, 0x52, 0x09, 0x3f, 0x41, 0x15, 0xdb, 0x71, 0x7c, 0x16, 0x04, 0x90,
+	0xbf, 0x86, 0xf5, 0x99, 0xac, 0x07, 0x0d, 0xbd, 0x89, 0xcc, 0xfd, 0x21, 0x0f, 0x47, 0xb3, 0x41,
+	0x97, 0x0a, 0xb7, 0x47, 0x45, 0xe0, 0x8a, 0x40, 0x4f, 0xfb, 0x81, 0x33, 0xee, 0xc9, 0x2b, 0x22,
+	0xe8, 0x1e, 0x52, 0x7a, 0xa8, 0x1c, 0x48, 0xe2, 0x89, 0xef, 0xa1, 0xfa, 0x54, 0x3c, 0x63, 0x7e,
+	0xff, 0xe9, 0xc4, 0x1e, 0x06, 0x46, 0xa1, 0x53, 0xd8, 0xab, 0x59, 0x37, 0x16, 0x91, 0x89, 0xce,
+	0x24, 0xfc, 0x85, 0x44, 0xe3, 0xc8, 0x44, 0xd3, 0x54, 0x23, 0x4b, 0x32, 0xfe, 0x01, 0xd5, 0x82,
+	0xd9, 0xc0, 0xe5, 0x61, 0xc8, 0x7c, 0xc8, 0x45, 0xc3, 0xba, 0x2f, 0x6f, 0x90, 0x14, 0xfc, 0xfb,
+	0x5b, 0xcb, 0x7c, 0x75, 0xe2, 0x7e, 0x2d, 0xa1, 0x6a, 0xc2, 0x9e, 0xd8, 0x44, 0x25, 0xc5, 0xb3,
+	0x2a, 0x5d, 0xb5, 0x38, 0x32, 0x15, 0x40, 0xd4, 0x84, 0x8f, 0x50, 0x79, 0x6a, 0xd3, 0x31, 0x53,
+	0x57, 0x60, 0xfd, 0xe0, 0xbd, 0x2e, 0x1f, 0xd0, 0xae, 0xbc, 0x6f, 0xbb, 0xc9, 0x25, 0x3b, 0xbf,
+	0xd3, 0x3d, 0x03, 0x13, 0xab, 0xa9, 0x8b, 0x40, 0xbb, 0x10, 0x3d, 0xe3, 0x7b, 0xa8, 0x65, 0xd3,
+	0xb1, 0x27, 0x9e, 0x4d, 0x98, 0x33, 0x64, 0xae, 0x7c, 0x5e, 0x01, 0x0e, 0x77, 0x35, 0x8e, 0xcc,
+	0xf5, 0x25, 0xb2, 0x0e, 0xc8, 0x4d, 0x0a, 0xdf, 0xd1, 0x19, 0xd1, 0x9b, 0x04, 0x80, 0xa8, 0x09,
+	0xdf, 0x45, 0x2d, 0x2a, 0x3c, 0x8f, 0x41, 0x15, 0xf4, 0x47, 0x62, 0x1a, 0x18, 0x25, 0xc8, 0x3c,
+	0x8e, 0x23, 0xb3, 0x99, 0x2d, 0x1d, 0x8b, 0x69, 0x40, 0xd6, 0x74, 0xbc, 0x8f, 0x2a, 0xd0, 0xf1,
+	0xdc, 0x31, 0xca, 0x10, 0x7f, 0x7b, 0x11, 0x99, 0x65, 0x59, 0x69, 0x40, 0x12, 0xe5, 0x29, 0x48,
+	0x44, 0xcd, 0x0e, 0xfe, 0x04, 0x21, 0x7d, 0x70, 0xe9, 0x51, 0x01, 0x8f, 0xeb, 0x8b, 0xc8, 0xac,
+	0x1d, 0x29, 0x14, 0x9c, 0x6a, 0x34, 0x51, 0x48, 0x2a, 0x3a, 0xf8, 0x7b, 0xd4, 0xa0, 0x62, 0xe6,
+	0x85, 0xcc, 0x9f, 0xda, 0x7e, 0x78, 0x6e, 0x54, 0xf5, 0x9d, 0x7f, 0x51, 0x46, 0x8f, 0x96, 0x0c,
+	0xad, 0x6d, 0x9d, 0xd7, 0x15, 0x77, 0xb2, 0xa2, 0xe1, 0xdb, 0xa8, 0x32, 0x67, 0x3e, 0xdc, 0xba,
+	0x35, 0xd8, 0x54, 0x5d, 0x56, 0xb4, 0x86, 0x48, 0x22, 0xe0, 0x53, 0xb4, 0xbd, 0xec, 0xd6, 0x4f,
+	0x7c, 0x10, 0xf8, 0xfc, 0x3f, 0x8e, 0xcc, 0xab, 0xcb, 0xeb, 0x4f, 0xb4, 0xff, 0x45, 0xe0, 0x6a,
+	0xb5, 0xd6, 0xff, 0x9b, 0x6a, 0x8d, 0xf3, 0xa8, 0xf9, 0x8d, 0x6a, 0xfe, 0x84, 0x13, 0x77, 0x51,
+	0xd9, 0x65, 0xe1, 0x48, 0x38, 0xba, 0x68, 0x81, 0x4f, 0x15, 0x42, 0xf4, 0x2c, 0x29, 0x67, 0xcc,
+	0x12, 0x6e, 0x04, 0xca, 0x19, 0xb3, 0x73, 0x22, 0x07, 0x59, 0x4d, 0x73, 0x7b, 0x32, 0x53, 0x5f,
+	0x68, 0xba, 0x9a, 0x00, 0x20, 0x6a, 0xc2, 0xc7, 0xa8, 0xc2, 0xbc, 0xd0, 0xe7, 0x2c, 0x30, 0x8a,
+	0x40, 0x7e, 0x37, 0x37, 0xc8, 0x4f, 0xef, 0xe8, 0xa1, 0x17, 0xfa, 0xe7, 0x56, 0x4b, 0xbf, 0x9d,
+	0xc4, 0x8b, 0x24, 0x82, 0x64, 0xd9, 0x31, 0x3b, 0x4f, 0x8a, 0x11, 0x58, 0x56, 0xea, 0x04, 0x46,
+	0x6c, 0xa1, 0x2a, 0x7b, 0x3e, 0x65, 0x34, 0x64, 0xaa, 0x8e, 0xea, 0x07, 0xc6, 0x65, 0x0f, 0x52,
+	0x3c, 0x96, 0x58, 0x93, 0x54, 0x92, 0x87, 0xb1, 0x9f, 0xca, 0xf4, 0x57, 0xb3, 0xc3, 0x00, 0x40,
+	0xd4, 0x24, 0x0d, 0x26, 0xdc, 0xe5, 0x21, 0x14, 0x45, 0x51, 0x19, 0x00, 0x40, 0xd4, 0xa4, 0xd2,
+	0x7c, 0x5a, 0xac, 0x96, 0xdb, 0x95, 0xdd, 0x28, 0x8f, 0x9a, 0xba, 0x8c, 0x93, 0x64, 0xbf, 0xfd,
+	0x33, 0x39, 0x7b, 0x15, 0xf9, 0x4b, 0x5f, 0x45, 0xc6, 0x20, 0x85, 0x77, 0x67, 0x90, 0xfb, 0xa8,
+	0xed, 0xb3, 0x89, 0x1d, 0xf2, 0x39, 0x83, 0xcf, 0x6b, 0x31, 0x53, 0x77, 0x45, 0x51, 0x51, 0x48,
+	0xb2, 0xf6, 0x58, 0x2d, 0x91, 0x75, 0x20, 0xa3, 0x90, 0xd2, 0x25, 0x14, 0x22, 0xaf, 0x70, 0xc9,
+	0x1b, 0xe5, 0xec, 0x55, 0x49, 0x9d, 0xc0, 0xb8, 0xdc, 0x5c, 0x95, 0xb7, 0x34, 0x97, 0xbe, 0xe8,
+	0xaa, 0xd0, 0x0a, 0x2b, 0x17, 0x9d, 0xfe, 0x04, 0xfc, 0xf6, 0xc5, 0x62, 0x27, 0xf7, 0x72, 0xb1,
+	0x93, 0xfb, 0x7d, 0xb1, 0x93, 0xfb, 0xf9, 0xf5, 0xce, 0xd6, 0xcb, 0xd7, 0x3b, 0x5b, 0xaf, 0x5e,
+	0xef, 0x6c, 0x7d, 0x77, 0x77, 0xa9, 0x4f, 0x0e, 0xd5, 0x0f, 0x8f, 0xec, 0x13, 0x4e, 0xf7, 0xd3,
+	0xff, 0x9e, 0xe7, 0xd9, 0x2f, 0x10, 0x97, 0xbd, 0xe8, 0xd9, 0x13, 0xd5, 0x40, 0x83, 0x32, 0xfc,
+	0xe7, 0x7c, 0xf8, 0xe7, 0x00, 0x61, 0xd8, 0xac, 0x87, 0x74, 0x0d, 0x00, 0x00,
+}
+
+func (m *ControllerAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ControllerAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size := m.Body.Size()
+			i -= size
+			if _, err := m.Body.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if m.BlockTime != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.BlockTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ControllerAction_BeginBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_BeginBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BeginBlock != nil {
+		{
+			size, err := m.BeginBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ControllerAction_EndBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_EndBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.EndBlock != nil {
+		{
+			size, err := m.EndBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ControllerAction_DeliverInbound) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_DeliverInbound) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DeliverInbound != nil {
+		{
+			size, err := m.DeliverInbound.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ControllerAction_Provision) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_Provision) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Provision != nil {
+		{
+			size, err := m.Provision.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ControllerAction_IbcEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_IbcEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.IbcEvent != nil {
+		{
+			size, err := m.IbcEvent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ControllerAction_ExportKernel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_ExportKernel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ExportKernel != nil {
+		{
+			size, err := m.ExportKernel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ControllerAction_ImportKernel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ControllerAction_ImportKernel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ImportKernel != nil {
+		{
+			size, err := m.ImportKernel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BeginBlockAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BeginBlockAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BeginBlockAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintController(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StoragePort != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.StoragePort))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EndBlockAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndBlockAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EndBlockAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.StoragePort))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportKernelAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportKernelAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportKernelAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.StoragePort))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportKernelAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportKernelAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportKernelAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bundle) > 0 {
+		i -= len(m.Bundle)
+		copy(dAtA[i:], m.Bundle)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Bundle)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ExportHeight != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.ExportHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintController(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StoragePort != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.StoragePort))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InboundMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InboundMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InboundMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Num != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.Num))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeliverInboundAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeliverInboundAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeliverInboundAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.StoragePort))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Ack != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.Ack))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintController(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Peer) > 0 {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProvisionAction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionAction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProvisionAction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Submitter) > 0 {
+		i -= len(m.Submitter)
+		copy(dAtA[i:], m.Submitter)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Submitter)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.PowerFlags) > 0 {
+		for iNdEx := len(m.PowerFlags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PowerFlags[iNdEx])
+			copy(dAtA[i:], m.PowerFlags[iNdEx])
+			i = encodeVarintController(dAtA, i, uint64(len(m.PowerFlags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Nickname) > 0 {
+		i -= len(m.Nickname)
+		copy(dAtA[i:], m.Nickname)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Nickname)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IBCEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IBCEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IBCEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Submitter) > 0 {
+		i -= len(m.Submitter)
+		copy(dAtA[i:], m.Submitter)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Submitter)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.CounterpartyVersion) > 0 {
+		i -= len(m.CounterpartyVersion)
+		copy(dAtA[i:], m.CounterpartyVersion)
+		i = encodeVarintController(dAtA, i, uint64(len(m.CounterpartyVersion)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	{
+		size, err := m.Counterparty.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintController(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintController(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.PortID) > 0 {
+		i -= len(m.PortID)
+		copy(dAtA[i:], m.PortID)
+		i = encodeVarintController(dAtA, i, uint64(len(m.PortID)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.ConnectionHops) > 0 {
+		for iNdEx := len(m.ConnectionHops) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ConnectionHops[iNdEx])
+			copy(dAtA[i:], m.ConnectionHops[iNdEx])
+			i = encodeVarintController(dAtA, i, uint64(len(m.ConnectionHops[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Order) > 0 {
+		i -= len(m.Order)
+		copy(dAtA[i:], m.Order)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Order)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Acknowledgement) > 0 {
+		i -= len(m.Acknowledgement)
+		copy(dAtA[i:], m.Acknowledgement)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Acknowledgement)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Packet.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintController(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Event) > 0 {
+		i -= len(m.Event)
+		copy(dAtA[i:], m.Event)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Event)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.After) > 0 {
+		i -= len(m.After)
+		copy(dAtA[i:], m.After)
+		i = encodeVarintController(dAtA, i, uint64(len(m.After)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Expected != nil {
+		{
+			size, err := m.Expected.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintController(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Keys[iNdEx])
+			copy(dAtA[i:], m.Keys[iNdEx])
+			i = encodeVarintController(dAtA, i, uint64(len(m.Keys[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintController(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ack) > 0 {
+		i -= len(m.Ack)
+		copy(dAtA[i:], m.Ack)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Ack)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Hops) > 0 {
+		for iNdEx := len(m.Hops) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Hops[iNdEx])
+			copy(dAtA[i:], m.Hops[iNdEx])
+			i = encodeVarintController(dAtA, i, uint64(len(m.Hops[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Order) > 0 {
+		i -= len(m.Order)
+		copy(dAtA[i:], m.Order)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Order)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.RelativeTimeout != 0 {
+		i = encodeVarintController(dAtA, i, uint64(m.RelativeTimeout))
+		i--
+		dAtA[i] = 0x20
+	}
+	{
+		size, err := m.Packet.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintController(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintController(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintController(dAtA []byte, offset int, v uint64) int {
+	offset -= sovController(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ControllerAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if m.BlockHeight != 0 {
+		n += 1 + sovController(uint64(m.BlockHeight))
+	}
+	if m.BlockTime != 0 {
+		n += 1 + sovController(uint64(m.BlockTime))
+	}
+	if m.Body != nil {
+		n += m.Body.Size()
+	}
+	return n
+}
+
+func (m *ControllerAction_BeginBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BeginBlock != nil {
+		l = m.BeginBlock.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *ControllerAction_EndBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EndBlock != nil {
+		l = m.EndBlock.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *ControllerAction_DeliverInbound) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeliverInbound != nil {
+		l = m.DeliverInbound.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *ControllerAction_Provision) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Provision != nil {
+		l = m.Provision.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *ControllerAction_IbcEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IbcEvent != nil {
+		l = m.IbcEvent.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *ControllerAction_ExportKernel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExportKernel != nil {
+		l = m.ExportKernel.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *ControllerAction_ImportKernel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ImportKernel != nil {
+		l = m.ImportKernel.Size()
+		n += 2 + l + sovController(uint64(l))
+	}
+	return n
+}
+func (m *BeginBlockAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		n += 1 + sovController(uint64(m.StoragePort))
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+
+func (m *EndBlockAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		n += 1 + sovController(uint64(m.StoragePort))
+	}
+	return n
+}
+
+func (m *ExportKernelAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		n += 1 + sovController(uint64(m.StoragePort))
+	}
+	return n
+}
+
+func (m *ImportKernelAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StoragePort != 0 {
+		n += 1 + sovController(uint64(m.StoragePort))
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if m.ExportHeight != 0 {
+		n += 1 + sovController(uint64(m.ExportHeight))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Bundle)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+
+func (m *InboundMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Num != 0 {
+		n += 1 + sovController(uint64(m.Num))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+
+func (m *DeliverInboundAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Peer)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovController(uint64(l))
+		}
+	}
+	if m.Ack != 0 {
+		n += 1 + sovController(uint64(m.Ack))
+	}
+	if m.StoragePort != 0 {
+		n += 1 + sovController(uint64(m.StoragePort))
+	}
+	return n
+}
+
+func (m *ProvisionAction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Nickname)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if len(m.PowerFlags) > 0 {
+		for _, s := range m.PowerFlags {
+			l = len(s)
+			n += 1 + l + sovController(uint64(l))
+		}
+	}
+	l = len(m.Submitter)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+
+func (m *IBCEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Event)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = m.Packet.Size()
+	n += 1 + l + sovController(uint64(l))
+	l = len(m.Acknowledgement)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Order)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if len(m.ConnectionHops) > 0 {
+		for _, s := range m.ConnectionHops {
+			l = len(s)
+			n += 1 + l + sovController(uint64(l))
+		}
+	}
+	l = len(m.PortID)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = m.Counterparty.Size()
+	n += 1 + l + sovController(uint64(l))
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.CounterpartyVersion)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Submitter)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+
+func (m *StorageMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovController(uint64(l))
+		}
+	}
+	if len(m.Keys) > 0 {
+		for _, s := range m.Keys {
+			l = len(s)
+			n += 1 + l + sovController(uint64(l))
+		}
+	}
+	if m.Expected != nil {
+		l = m.Expected.Size()
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.After)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovController(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *ChannelMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = m.Packet.Size()
+	n += 1 + l + sovController(uint64(l))
+	if m.RelativeTimeout != 0 {
+		n += 1 + sovController(uint64(m.RelativeTimeout))
+	}
+	l = len(m.Order)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	if len(m.Hops) > 0 {
+		for _, s := range m.Hops {
+			l = len(s)
+			n += 1 + l + sovController(uint64(l))
+		}
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	l = len(m.Ack)
+	if l > 0 {
+		n += 1 + l + sovController(uint64(l))
+	}
+	return n
+}
+
+func sovController(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozController(x uint64) (n int) {
+	return sovController(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ControllerAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ControllerAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ControllerAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTime", wireType)
+			}
+			m.BlockTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BeginBlockAction{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_BeginBlock{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &EndBlockAction{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_EndBlock{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverInbound", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DeliverInboundAction{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_DeliverInbound{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provision", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ProvisionAction{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_Provision{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IbcEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IBCEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_IbcEvent{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExportKernel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ExportKernelAction{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_ExportKernel{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImportKernel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ImportKernelAction{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Body = &ControllerAction_ImportKernel{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BeginBlockAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BeginBlockAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BeginBlockAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoragePort", wireType)
+			}
+			m.StoragePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoragePort |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndBlockAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndBlockAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndBlockAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoragePort", wireType)
+			}
+			m.StoragePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoragePort |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportKernelAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportKernelAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportKernelAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoragePort", wireType)
+			}
+			m.StoragePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoragePort |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportKernelAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportKernelAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportKernelAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoragePort", wireType)
+			}
+			m.StoragePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoragePort |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExportHeight", wireType)
+			}
+			m.ExportHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExportHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bundle = append(m.Bundle[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bundle == nil {
+				m.Bundle = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InboundMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InboundMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InboundMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Num", wireType)
+			}
+			m.Num = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Num |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Body = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeliverInboundAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeliverInboundAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeliverInboundAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, InboundMessage{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
+			}
+			m.Ack = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ack |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoragePort", wireType)
+			}
+			m.StoragePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoragePort |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProvisionAction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProvisionAction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProvisionAction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nickname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nickname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PowerFlags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PowerFlags = append(m.PowerFlags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Submitter", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Submitter = append(m.Submitter[:0], dAtA[iNdEx:postIndex]...)
+			if m.Submitter == nil {
+				m.Submitter = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IBCEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IBCEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IBCEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Event = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Packet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Packet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Acknowledgement", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Acknowledgement = append(m.Acknowledgement[:0], dAtA[iNdEx:postIndex]...)
+			if m.Acknowledgement == nil {
+				m.Acknowledgement = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Order", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Order = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionHops", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnectionHops = append(m.ConnectionHops, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counterparty", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Counterparty.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CounterpartyVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CounterpartyVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Submitter", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Submitter = append(m.Submitter[:0], dAtA[iNdEx:postIndex]...)
+			if m.Submitter == nil {
+				m.Submitter = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, StorageEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expected == nil {
+				m.Expected = &Storage{}
+			}
+			if err := m.Expected.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.After = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Packet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Packet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelativeTimeout", wireType)
+			}
+			m.RelativeTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RelativeTimeout |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Order", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Order = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hops", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hops = append(m.Hops, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthController
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthController
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ack = append(m.Ack[:0], dAtA[iNdEx:postIndex]...)
+			if m.Ack == nil {
+				m.Ack = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipController(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthController
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipController(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowController
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowController
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthController
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupController
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthController
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthController        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowController          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupController = fmt.Errorf("proto: unexpected end of group")
+)
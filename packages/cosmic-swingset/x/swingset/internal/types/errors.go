@@ -0,0 +1,13 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Storage limit errors, returned to the controller when it would exceed the
+// module parameters.
+var (
+	ErrValueTooLarge        = sdkerrors.Register(ModuleName, 2, "storage value too large")
+	ErrTooManyChildren      = sdkerrors.Register(ModuleName, 3, "too many storage children")
+	ErrStorageQuotaExceeded = sdkerrors.Register(ModuleName, 4, "storage quota exceeded")
+)
@@ -0,0 +1,21 @@
+package types
+
+// Storage change events, emitted for every write to SwingSet storage.
+const (
+	EventTypeStorage = "swingset_storage"
+
+	AttributeKeyPath      = "path"
+	AttributeKeyOperation = "operation"
+	// AttributeKeyValue is the new value, if it is at most
+	// MaxStorageEventValueSize bytes.
+	AttributeKeyValue = "value"
+	// AttributeKeyValueHash is the hex SHA-256 of a larger new value.
+	AttributeKeyValueHash = "value_hash"
+
+	StorageOperationSet    = "set"
+	StorageOperationDelete = "delete"
+
+	// MaxStorageEventValueSize is the largest value that is included in a
+	// storage event.
+	MaxStorageEventValueSize = 1024
+)
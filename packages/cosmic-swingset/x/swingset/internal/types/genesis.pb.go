@@ -25,6 +25,13 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type GenesisState struct {
 	Storage map[string]string `protobuf:"bytes,1,rep,name=storage,proto3" json:"storage" yaml:"storage" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Params  Params            `protobuf:"bytes,2,opt,name=params,proto3" json:"params" yaml:"params"`
+	// StorageFiles, if set, holds the storage in files beside genesis.json,
+	// rather than in Storage.
+	StorageFiles *StorageFiles `protobuf:"bytes,3,opt,name=storage_files,json=storageFiles,proto3" json:"storage_files,omitempty" yaml:"storage_files,omitempty"`
+	// KernelExport, if set, is the SwingSet kernel state to hand back to the
+	// controller when the chain starts.
+	KernelExport *KernelExport `protobuf:"bytes,4,opt,name=kernel_export,json=kernelExport,proto3" json:"kernel_export,omitempty" yaml:"kernel_export,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -67,32 +74,216 @@ func (m *GenesisState) GetStorage() map[string]string {
 	return nil
 }
 
+func (m *GenesisState) GetParams() Params {
+	if m != nil {
+		return m.Params
+	}
+	return Params{}
+}
+
+func (m *GenesisState) GetStorageFiles() *StorageFiles {
+	if m != nil {
+		return m.StorageFiles
+	}
+	return nil
+}
+
+func (m *GenesisState) GetKernelExport() *KernelExport {
+	if m != nil {
+		return m.KernelExport
+	}
+	return nil
+}
+
+// StorageFiles describes storage exported as JSON Lines files, in path order.
+type StorageFiles struct {
+	// Files are the names of the files, relative to genesis.json.
+	Files []string `protobuf:"bytes,1,rep,name=files,proto3" json:"files" yaml:"files"`
+	// Entries is the number of storage entries in all the files.
+	Entries uint64 `protobuf:"varint,2,opt,name=entries,proto3" json:"entries" yaml:"entries"`
+	// Checksum is the hex SHA-256 of the entries, each as the uvarint length
+	// and bytes of its path, then of its value.
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum" yaml:"checksum"`
+}
+
+func (m *StorageFiles) Reset()         { *m = StorageFiles{} }
+func (m *StorageFiles) String() string { return proto.CompactTextString(m) }
+func (*StorageFiles) ProtoMessage()    {}
+func (*StorageFiles) Descriptor() ([]byte, []int) {
+	return fileDescriptor_49b057311de9d296, []int{1}
+}
+func (m *StorageFiles) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageFiles) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageFiles.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageFiles) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageFiles.Merge(m, src)
+}
+func (m *StorageFiles) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageFiles) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageFiles.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageFiles proto.InternalMessageInfo
+
+func (m *StorageFiles) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *StorageFiles) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+func (m *StorageFiles) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+// KernelExport is the kernel state that the controller returned for
+// EXPORT_KERNEL.  The bundle is either inline, in a file beside genesis.json,
+// or, if neither, found by the controller from its hash.
+type KernelExport struct {
+	// BlockHeight is the last block that the exported state includes.
+	BlockHeight int64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height" yaml:"block_height"`
+	// Hash is the hex SHA-256 of the bundle.
+	Hash   string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash" yaml:"hash"`
+	Bundle []byte `protobuf:"bytes,3,opt,name=bundle,proto3" json:"bundle,omitempty" yaml:"bundle,omitempty"`
+	// File is the name of the bundle's file, relative to genesis.json.
+	File string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+func (m *KernelExport) Reset()         { *m = KernelExport{} }
+func (m *KernelExport) String() string { return proto.CompactTextString(m) }
+func (*KernelExport) ProtoMessage()    {}
+func (*KernelExport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_49b057311de9d296, []int{2}
+}
+func (m *KernelExport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KernelExport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KernelExport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KernelExport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KernelExport.Merge(m, src)
+}
+func (m *KernelExport) XXX_Size() int {
+	return m.Size()
+}
+func (m *KernelExport) XXX_DiscardUnknown() {
+	xxx_messageInfo_KernelExport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KernelExport proto.InternalMessageInfo
+
+func (m *KernelExport) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *KernelExport) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *KernelExport) GetBundle() []byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *KernelExport) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "agoric.swingset.GenesisState")
 	proto.RegisterMapType((map[string]string)(nil), "agoric.swingset.GenesisState.StorageEntry")
+	proto.RegisterType((*StorageFiles)(nil), "agoric.swingset.StorageFiles")
+	proto.RegisterType((*KernelExport)(nil), "agoric.swingset.KernelExport")
 }
 
 func init() { proto.RegisterFile("agoric/swingset/genesis.proto", fileDescriptor_49b057311de9d296) }
 
 var fileDescriptor_49b057311de9d296 = []byte{
-	// 268 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4d, 0x4c, 0xcf, 0x2f,
-	0xca, 0x4c, 0xd6, 0x2f, 0x2e, 0xcf, 0xcc, 0x4b, 0x2f, 0x4e, 0x2d, 0xd1, 0x4f, 0x4f, 0xcd, 0x4b,
-	0x2d, 0xce, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x87, 0x48, 0xeb, 0xc1, 0xa4,
-	0xa5, 0x44, 0xd2, 0xf3, 0xd3, 0xf3, 0xc1, 0x72, 0xfa, 0x20, 0x16, 0x44, 0x99, 0xd2, 0x56, 0x46,
-	0x2e, 0x1e, 0x77, 0x88, 0xc6, 0xe0, 0x92, 0xc4, 0x92, 0x54, 0xa1, 0x64, 0x2e, 0xf6, 0xe2, 0x92,
-	0xfc, 0xa2, 0xc4, 0xf4, 0x54, 0x09, 0x46, 0x05, 0x66, 0x0d, 0x6e, 0x23, 0x2d, 0x3d, 0x34, 0x93,
-	0xf4, 0x90, 0xd5, 0xeb, 0x05, 0x43, 0x14, 0xbb, 0xe6, 0x95, 0x14, 0x55, 0x3a, 0xc9, 0xbe, 0xba,
-	0x27, 0x0f, 0xd3, 0xfe, 0xe9, 0x9e, 0x3c, 0x5f, 0x65, 0x62, 0x6e, 0x8e, 0x95, 0x12, 0x54, 0x40,
-	0x29, 0x08, 0x26, 0x25, 0x65, 0xc5, 0xc5, 0x83, 0xac, 0x4f, 0x48, 0x80, 0x8b, 0x39, 0x3b, 0xb5,
-	0x52, 0x82, 0x51, 0x81, 0x51, 0x83, 0x33, 0x08, 0xc4, 0x14, 0x12, 0xe1, 0x62, 0x2d, 0x4b, 0xcc,
-	0x29, 0x4d, 0x95, 0x60, 0x02, 0x8b, 0x41, 0x38, 0x56, 0x4c, 0x16, 0x8c, 0x56, 0x2c, 0x2f, 0x16,
-	0xc8, 0x33, 0x38, 0x85, 0x9e, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72,
-	0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x75,
-	0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0xbe, 0x23, 0x24, 0x88, 0x92, 0xf3,
-	0x8b, 0x73, 0x33, 0x93, 0x75, 0xe1, 0x21, 0x55, 0x81, 0x08, 0xb4, 0xcc, 0xbc, 0x92, 0xd4, 0xa2,
-	0xbc, 0xc4, 0x1c, 0xfd, 0x92, 0xca, 0x82, 0xd4, 0xe2, 0x24, 0x36, 0x70, 0xa8, 0x18, 0x03, 0x02,
-	0x00, 0x00, 0xff, 0xff, 0xb2, 0xfd, 0x32, 0x30, 0x5d, 0x01, 0x00, 0x00,
+	// 621 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0x8d, 0x1b, 0xb7, 0xfd, 0x32, 0x71, 0x7f, 0x34, 0x5f, 0x51, 0x42, 0x45, 0x3d, 0xd5, 0x6c,
+	0x88, 0x28, 0xd8, 0x52, 0x59, 0x80, 0x52, 0x21, 0x84, 0xa5, 0x52, 0x04, 0x9b, 0x6a, 0x2a, 0x36,
+	0x6c, 0x22, 0xc7, 0x4c, 0x6d, 0x2b, 0xfe, 0x89, 0xec, 0x09, 0x34, 0x4b, 0x9e, 0x00, 0x76, 0x6c,
+	0x79, 0x0f, 0x5e, 0xa0, 0xcb, 0x2e, 0x59, 0x8d, 0x50, 0xb2, 0x41, 0x5e, 0xfa, 0x09, 0x90, 0x67,
+	0x9c, 0xc4, 0x49, 0xe9, 0x6e, 0xee, 0x39, 0xe7, 0x9e, 0xb9, 0x73, 0xef, 0xe8, 0x82, 0x03, 0xdb,
+	0x8d, 0x13, 0xdf, 0x31, 0xd3, 0xcf, 0x7e, 0xe4, 0xa6, 0x94, 0x99, 0x2e, 0x8d, 0x68, 0xea, 0xa7,
+	0xc6, 0x30, 0x89, 0x59, 0x0c, 0x77, 0x24, 0x6d, 0xcc, 0xe8, 0xfd, 0x3d, 0x37, 0x76, 0x63, 0xc1,
+	0x99, 0xc5, 0x49, 0xca, 0xf6, 0x1f, 0xac, 0xba, 0x0c, 0xed, 0xc4, 0x0e, 0x4b, 0x13, 0xfc, 0x55,
+	0x05, 0xda, 0x99, 0xb4, 0xbd, 0x60, 0x36, 0xa3, 0xd0, 0x01, 0x9b, 0x29, 0x8b, 0x13, 0xdb, 0xa5,
+	0x6d, 0xe5, 0xb0, 0xde, 0x69, 0x1e, 0x3f, 0x32, 0x56, 0xee, 0x31, 0xaa, 0x7a, 0xe3, 0x42, 0x8a,
+	0x4f, 0x23, 0x96, 0x8c, 0xad, 0x83, 0x8c, 0xa3, 0x59, 0x7a, 0xce, 0xd1, 0xf6, 0xd8, 0x0e, 0x83,
+	0x2e, 0x2e, 0x01, 0x4c, 0x66, 0x14, 0x3c, 0x07, 0x1b, 0xb2, 0x8a, 0xf6, 0xda, 0xa1, 0xd2, 0x69,
+	0x1e, 0xb7, 0x6e, 0xdd, 0x71, 0x2e, 0x68, 0x0b, 0x5d, 0x73, 0x54, 0xcb, 0x38, 0x2a, 0xe5, 0x39,
+	0x47, 0x5b, 0xd2, 0x53, 0xc6, 0x98, 0x94, 0x04, 0xfc, 0xa2, 0x80, 0xad, 0xd2, 0xbd, 0x77, 0xe9,
+	0x07, 0x34, 0x6d, 0xd7, 0x85, 0xf3, 0xc1, 0x2d, 0xe7, 0xb2, 0xe0, 0xd7, 0x85, 0xc8, 0x7a, 0x91,
+	0x71, 0xd4, 0x5a, 0xca, 0x7b, 0x1c, 0x87, 0x3e, 0xa3, 0xe1, 0x90, 0x8d, 0x73, 0x8e, 0xf4, 0xa5,
+	0x07, 0xac, 0x0a, 0x30, 0xd1, 0xd2, 0x8a, 0x99, 0xa8, 0x61, 0x40, 0x93, 0x88, 0x06, 0x3d, 0x7a,
+	0x35, 0x8c, 0x13, 0xd6, 0x56, 0xef, 0xa8, 0xe1, 0x9d, 0x50, 0x9d, 0x0a, 0x91, 0xac, 0x61, 0x29,
+	0xef, 0x5f, 0x35, 0xdc, 0x21, 0xc0, 0x44, 0x1b, 0x54, 0xcc, 0xf6, 0xbb, 0x40, 0xab, 0x4e, 0x04,
+	0xee, 0x82, 0xfa, 0x80, 0x8e, 0xdb, 0xca, 0xa1, 0xd2, 0x69, 0x90, 0xe2, 0x08, 0xf7, 0xc0, 0xfa,
+	0x27, 0x3b, 0x18, 0x51, 0xd1, 0xfa, 0x06, 0x91, 0x41, 0x77, 0xed, 0xb9, 0xd2, 0x55, 0xff, 0xfc,
+	0x40, 0x35, 0xfc, 0x53, 0x99, 0x5b, 0xc8, 0x67, 0x99, 0x60, 0x5d, 0x76, 0xb4, 0xf8, 0x0f, 0x0d,
+	0xeb, 0x7e, 0xc6, 0x91, 0x04, 0x72, 0x8e, 0x34, 0x59, 0x9c, 0x08, 0x31, 0x91, 0x30, 0x7c, 0x06,
+	0x36, 0x69, 0xc4, 0x12, 0x9f, 0xca, 0xf1, 0xaa, 0xf2, 0x5b, 0x94, 0xd0, 0xe2, 0x5b, 0x94, 0x00,
+	0x26, 0x33, 0x0a, 0x9e, 0x80, 0xff, 0x1c, 0x8f, 0x3a, 0x83, 0x74, 0x14, 0x8a, 0xf1, 0x35, 0x2c,
+	0x94, 0x71, 0x34, 0xc7, 0x72, 0x8e, 0x76, 0x64, 0xea, 0x0c, 0xc1, 0x64, 0x4e, 0x96, 0xd5, 0x7f,
+	0x5f, 0x03, 0x5a, 0xb5, 0xbb, 0xf0, 0x2d, 0xd0, 0xfa, 0x41, 0xec, 0x0c, 0x7a, 0x1e, 0xf5, 0x5d,
+	0x8f, 0x89, 0x4e, 0xd4, 0xad, 0x87, 0x19, 0x47, 0x4b, 0x78, 0xce, 0xd1, 0xff, 0xd2, 0xbb, 0x8a,
+	0x62, 0xd2, 0x14, 0xe1, 0x1b, 0x11, 0xc1, 0x23, 0xa0, 0x7a, 0x76, 0xea, 0xc9, 0xce, 0x59, 0xad,
+	0x8c, 0x23, 0x11, 0xe7, 0x1c, 0x35, 0x65, 0x6e, 0x11, 0x61, 0x22, 0x40, 0x78, 0x06, 0x36, 0xfa,
+	0xa3, 0xe8, 0x63, 0x40, 0xc5, 0x53, 0x34, 0xcb, 0xcc, 0x38, 0xda, 0x95, 0xc8, 0xd2, 0x7c, 0x5b,
+	0xe5, 0xb5, 0x2b, 0x0c, 0x26, 0x65, 0x3a, 0x7c, 0x09, 0xd4, 0xa2, 0xaf, 0xe2, 0x33, 0x35, 0xac,
+	0xa3, 0x8c, 0xa3, 0xed, 0x4b, 0xbf, 0x2a, 0xcd, 0x39, 0xba, 0xb7, 0x98, 0x43, 0xd5, 0x42, 0x24,
+	0xca, 0xce, 0x58, 0xef, 0xaf, 0x27, 0xba, 0x72, 0x33, 0xd1, 0x95, 0xdf, 0x13, 0x5d, 0xf9, 0x36,
+	0xd5, 0x6b, 0x37, 0x53, 0xbd, 0xf6, 0x6b, 0xaa, 0xd7, 0x3e, 0x9c, 0xb8, 0x3e, 0xf3, 0x46, 0x7d,
+	0xc3, 0x89, 0x43, 0xf3, 0x95, 0x5c, 0x16, 0x4e, 0x9c, 0x86, 0xbe, 0xf3, 0x64, 0xbe, 0x33, 0xae,
+	0x16, 0xeb, 0xc3, 0x8f, 0x18, 0x4d, 0x22, 0x3b, 0x30, 0xd9, 0x78, 0x48, 0xd3, 0xfe, 0x86, 0xd8,
+	0x23, 0x4f, 0xff, 0x0e, 0x00, 0xe2, 0xc4, 0x33, 0xae, 0xad, 0x04, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -115,6 +306,40 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.KernelExport != nil {
+		{
+			size, err := m.KernelExport.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StorageFiles != nil {
+		{
+			size, err := m.StorageFiles.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
 	if len(m.Storage) > 0 {
 		for k := range m.Storage {
 			v := m.Storage[k]
@@ -137,6 +362,99 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StorageFiles) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageFiles) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageFiles) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Checksum) > 0 {
+		i -= len(m.Checksum)
+		copy(dAtA[i:], m.Checksum)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Checksum)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Entries != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.Entries))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Files) > 0 {
+		for iNdEx := len(m.Files) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Files[iNdEx])
+			copy(dAtA[i:], m.Files[iNdEx])
+			i = encodeVarintGenesis(dAtA, i, uint64(len(m.Files[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KernelExport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KernelExport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KernelExport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Bundle) > 0 {
+		i -= len(m.Bundle)
+		copy(dAtA[i:], m.Bundle)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Bundle)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGenesis(v)
 	base := offset
@@ -162,6 +480,62 @@ func (m *GenesisState) Size() (n int) {
 			n += mapEntrySize + 1 + sovGenesis(uint64(mapEntrySize))
 		}
 	}
+	l = m.Params.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	if m.StorageFiles != nil {
+		l = m.StorageFiles.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.KernelExport != nil {
+		l = m.KernelExport.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	return n
+}
+
+func (m *StorageFiles) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Files) > 0 {
+		for _, s := range m.Files {
+			l = len(s)
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.Entries != 0 {
+		n += 1 + sovGenesis(uint64(m.Entries))
+	}
+	l = len(m.Checksum)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	return n
+}
+
+func (m *KernelExport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovGenesis(uint64(m.BlockHeight))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.Bundle)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
 	return n
 }
 
@@ -327,6 +701,417 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			}
 			m.Storage[mapkey] = mapvalue
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageFiles", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StorageFiles == nil {
+				m.StorageFiles = &StorageFiles{}
+			}
+			if err := m.StorageFiles.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KernelExport", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.KernelExport == nil {
+				m.KernelExport = &KernelExport{}
+			}
+			if err := m.KernelExport.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageFiles) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageFiles: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageFiles: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Files = append(m.Files, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			m.Entries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Entries |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksum = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KernelExport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KernelExport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KernelExport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bundle = append(m.Bundle[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bundle == nil {
+				m.Bundle = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.File = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+)
+
 const (
 	// module name
 	ModuleName = "swingset"
@@ -8,8 +13,77 @@ const (
 	StoreKey = ModuleName
 )
 
+// The storage layout keys each path by its EncodePath in DataPrefix, by its
+// DepthKey in KeysPrefix under its parent, and by its DepthKey in UsagePrefix.
 var (
-	DataPrefix   = []byte(StoreKey + "/data")
-	KeysPrefix   = []byte(StoreKey + "/keys")
+	DataPrefix   = []byte(StoreKey + "/v2/data")
+	KeysPrefix   = []byte(StoreKey + "/v2/keys")
+	UsagePrefix  = []byte(StoreKey + "/v2/usage")
 	EgressPrefix = []byte(StoreKey + "/egress")
 )
+
+// The legacy storage layout kept a length-prefixed Storage message under each
+// "."-separated path, where an empty value could not be stored.
+var (
+	LegacyDataPrefix  = []byte(StoreKey + "/data")
+	LegacyKeysPrefix  = []byte(StoreKey + "/keys")
+	LegacyUsagePrefix = []byte(StoreKey + "/usage")
+)
+
+// ChildKeyValue is the value of every child key entry.
+var ChildKeyValue = []byte{1}
+
+// storeValueFormat precedes each value in DataPrefix, so that an empty value
+// is not an empty, and thus deleted, store entry.
+const storeValueFormat = 1
+
+// StoreValue returns the store encoding of a storage value.
+func StoreValue(value []byte) []byte {
+	return append([]byte{storeValueFormat}, value...)
+}
+
+// ParseStoreValue returns the storage value of a store encoding.
+func ParseStoreValue(bz []byte) ([]byte, error) {
+	if len(bz) == 0 || bz[0] != storeValueFormat {
+		return nil, fmt.Errorf("malformed storage value")
+	}
+	return bz[1:], nil
+}
+
+// UsageKey is the key, within UsagePrefix, of the usage of path.
+func UsageKey(path string) []byte {
+	return DepthKey(path)
+}
+
+// ChildKeysPrefix is the prefix, within KeysPrefix, of the entries for
+// the children of parent.
+func ChildKeysPrefix(parent string) []byte {
+	return append(append([]byte{}, KeysPrefix...), ChildrenPrefix(parent)...)
+}
+
+// ChildKey is the key, within KeysPrefix, that lists path under its parent.
+func ChildKey(path string) []byte {
+	return DepthKey(path)
+}
+
+// ChildKeyAfter is the first key, within the ChildKeysPrefix of a parent,
+// that lists a child after the child key.
+func ChildKeyAfter(key string) []byte {
+	return append(EncodePath(EscapePathSegment(key)), segmentEnd)
+}
+
+// DataKey is the store key of the data at path.
+func DataKey(path string) []byte {
+	return append(append([]byte{}, DataPrefix...), EncodePath(path)...)
+}
+
+// ChildKeyPrefixed is the store key that lists path under its parent.
+func ChildKeyPrefixed(path string) []byte {
+	return append(append([]byte{}, KeysPrefix...), ChildKey(path)...)
+}
+
+// LegacyPath returns the storage path of a legacy "."-separated path, whose
+// segments may contain characters that now need escaping.
+func LegacyPath(legacy string) string {
+	return PathString(strings.Split(legacy, "."))
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// Parameter keys
+var (
+	KeyMaxValueSize  = []byte("MaxValueSize")
+	KeyMaxChildren   = []byte("MaxChildren")
+	KeyStorageQuotas = []byte("StorageQuotas")
+	KeyStorageGas    = []byte("StorageGas")
+	KeyBlockGasLimit = []byte("BlockStorageGasLimit")
+)
+
+var _ paramtypes.ParamSet = &Params{}
+
+// ParamKeyTable returns the key table for the swingset module parameters.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// DefaultParams returns the default parameters, which are unlimited and
+// charge no gas.
+func DefaultParams() Params {
+	return Params{
+		StorageQuotas: []StorageQuota{},
+	}
+}
+
+// ParamSetPairs implements params.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyMaxValueSize, &p.MaxValueSize, validateUint64),
+		paramtypes.NewParamSetPair(KeyMaxChildren, &p.MaxChildren, validateUint64),
+		paramtypes.NewParamSetPair(KeyStorageQuotas, &p.StorageQuotas, validateStorageQuotas),
+		paramtypes.NewParamSetPair(KeyStorageGas, &p.StorageGas, validateStorageGas),
+		paramtypes.NewParamSetPair(KeyBlockGasLimit, &p.BlockStorageGasLimit, validateUint64),
+	}
+}
+
+// ValidateBasic performs basic validation on the parameters.
+func (p Params) ValidateBasic() error {
+	return validateStorageQuotas(p.StorageQuotas)
+}
+
+// QuotasFor returns the storage quotas that apply to path.
+func (p Params) QuotasFor(path string) []StorageQuota {
+	var quotas []StorageQuota
+	for _, quota := range p.StorageQuotas {
+		if quota.Path == "" || path == quota.Path || strings.HasPrefix(path, quota.Path+".") {
+			quotas = append(quotas, quota)
+		}
+	}
+	return quotas
+}
+
+func validateUint64(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateStorageGas(i interface{}) error {
+	if _, ok := i.(StorageGas); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateStorageQuotas(i interface{}) error {
+	quotas, ok := i.([]StorageQuota)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(quotas))
+	for _, quota := range quotas {
+		if seen[quota.Path] {
+			return fmt.Errorf("duplicate storage quota for %q", quota.Path)
+		}
+		seen[quota.Path] = true
+		if quota.MaxBytes == 0 {
+			return fmt.Errorf("storage quota for %q must have max_bytes", quota.Path)
+		}
+	}
+	return nil
+}
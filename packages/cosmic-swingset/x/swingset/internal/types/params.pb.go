@@ -0,0 +1,1156 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: agoric/swingset/params.proto
+
+package types
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Params are the swingset module parameters.  Zero limits are unlimited.
+type Params struct {
+	// MaxValueSize is the largest value the controller may store.
+	MaxValueSize uint64 `protobuf:"varint,1,opt,name=max_value_size,json=maxValueSize,proto3" json:"max_value_size" yaml:"max_value_size"`
+	// MaxChildren is the most children the controller may give a path.
+	MaxChildren uint64 `protobuf:"varint,2,opt,name=max_children,json=maxChildren,proto3" json:"max_children" yaml:"max_children"`
+	// StorageQuotas cap the bytes at and below some paths.
+	StorageQuotas []StorageQuota `protobuf:"bytes,3,rep,name=storage_quotas,json=storageQuotas,proto3" json:"storage_quotas" yaml:"storage_quotas"`
+	// StorageGas is the gas charged for the controller's storage downcalls.
+	StorageGas StorageGas `protobuf:"bytes,4,opt,name=storage_gas,json=storageGas,proto3" json:"storage_gas" yaml:"storage_gas"`
+	// BlockStorageGasLimit is the storage gas the controller may use during
+	// BeginBlock and EndBlock together.
+	BlockStorageGasLimit uint64 `protobuf:"varint,5,opt,name=block_storage_gas_limit,json=blockStorageGasLimit,proto3" json:"block_storage_gas_limit" yaml:"block_storage_gas_limit"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9523063bff50464, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetMaxValueSize() uint64 {
+	if m != nil {
+		return m.MaxValueSize
+	}
+	return 0
+}
+
+func (m *Params) GetMaxChildren() uint64 {
+	if m != nil {
+		return m.MaxChildren
+	}
+	return 0
+}
+
+func (m *Params) GetStorageQuotas() []StorageQuota {
+	if m != nil {
+		return m.StorageQuotas
+	}
+	return nil
+}
+
+func (m *Params) GetStorageGas() StorageGas {
+	if m != nil {
+		return m.StorageGas
+	}
+	return StorageGas{}
+}
+
+func (m *Params) GetBlockStorageGasLimit() uint64 {
+	if m != nil {
+		return m.BlockStorageGasLimit
+	}
+	return 0
+}
+
+// StorageGas is the gas schedule for storage downcalls.  Zero costs are free.
+type StorageGas struct {
+	// Read is charged for each key read.
+	Read uint64 `protobuf:"varint,1,opt,name=read,proto3" json:"read" yaml:"read"`
+	// Write is charged for each key written or deleted.
+	Write uint64 `protobuf:"varint,2,opt,name=write,proto3" json:"write" yaml:"write"`
+	// PerByte is charged for each byte of the downcall and its reply.
+	PerByte uint64 `protobuf:"varint,3,opt,name=per_byte,json=perByte,proto3" json:"per_byte" yaml:"per_byte"`
+}
+
+func (m *StorageGas) Reset()         { *m = StorageGas{} }
+func (m *StorageGas) String() string { return proto.CompactTextString(m) }
+func (*StorageGas) ProtoMessage()    {}
+func (*StorageGas) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9523063bff50464, []int{1}
+}
+func (m *StorageGas) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageGas) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageGas.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageGas) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageGas.Merge(m, src)
+}
+func (m *StorageGas) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageGas) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageGas.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageGas proto.InternalMessageInfo
+
+func (m *StorageGas) GetRead() uint64 {
+	if m != nil {
+		return m.Read
+	}
+	return 0
+}
+
+func (m *StorageGas) GetWrite() uint64 {
+	if m != nil {
+		return m.Write
+	}
+	return 0
+}
+
+func (m *StorageGas) GetPerByte() uint64 {
+	if m != nil {
+		return m.PerByte
+	}
+	return 0
+}
+
+// StorageQuota caps the bytes of the paths and values at and below a path.
+type StorageQuota struct {
+	Path     string `protobuf:"bytes,1,opt,name=path,proto3" json:"path" yaml:"path"`
+	MaxBytes uint64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes" yaml:"max_bytes"`
+}
+
+func (m *StorageQuota) Reset()         { *m = StorageQuota{} }
+func (m *StorageQuota) String() string { return proto.CompactTextString(m) }
+func (*StorageQuota) ProtoMessage()    {}
+func (*StorageQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9523063bff50464, []int{2}
+}
+func (m *StorageQuota) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageQuota.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageQuota.Merge(m, src)
+}
+func (m *StorageQuota) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageQuota proto.InternalMessageInfo
+
+func (m *StorageQuota) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StorageQuota) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+// StorageUsage is the storage used at and below a path.
+type StorageUsage struct {
+	// Bytes is the total length of the paths and values.
+	Bytes uint64 `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes" yaml:"bytes"`
+	// Children is the number of children of the path.
+	Children uint64 `protobuf:"varint,2,opt,name=children,proto3" json:"children" yaml:"children"`
+}
+
+func (m *StorageUsage) Reset()         { *m = StorageUsage{} }
+func (m *StorageUsage) String() string { return proto.CompactTextString(m) }
+func (*StorageUsage) ProtoMessage()    {}
+func (*StorageUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a9523063bff50464, []int{3}
+}
+func (m *StorageUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageUsage.Merge(m, src)
+}
+func (m *StorageUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageUsage proto.InternalMessageInfo
+
+func (m *StorageUsage) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *StorageUsage) GetChildren() uint64 {
+	if m != nil {
+		return m.Children
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "agoric.swingset.Params")
+	proto.RegisterType((*StorageGas)(nil), "agoric.swingset.StorageGas")
+	proto.RegisterType((*StorageQuota)(nil), "agoric.swingset.StorageQuota")
+	proto.RegisterType((*StorageUsage)(nil), "agoric.swingset.StorageUsage")
+}
+
+func init() { proto.RegisterFile("agoric/swingset/params.proto", fileDescriptor_a9523063bff50464) }
+
+var fileDescriptor_a9523063bff50464 = []byte{
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x8e, 0x49, 0x5a, 0xd2, 0x4d, 0x68, 0xd1, 0x52, 0x54, 0xf3, 0xe7, 0x0d, 0x7b, 0x21, 0xa8,
+	0x22, 0x96, 0xca, 0x2d, 0x15, 0x48, 0x84, 0x03, 0x12, 0xe2, 0x40, 0x5d, 0x95, 0x03, 0x17, 0x6b,
+	0xe3, 0xae, 0x1c, 0x0b, 0x3b, 0x36, 0xde, 0x0d, 0x4d, 0x7a, 0x03, 0x5e, 0x80, 0x47, 0xe0, 0x01,
+	0x78, 0x90, 0x1e, 0x7b, 0xe4, 0xb4, 0x42, 0xc9, 0x05, 0xf9, 0xe8, 0x27, 0xa8, 0x76, 0xd7, 0x89,
+	0x93, 0x48, 0xb9, 0xf9, 0xfb, 0xe6, 0x9b, 0x99, 0x6f, 0x67, 0xbd, 0x03, 0x1e, 0x13, 0x3f, 0x4e,
+	0x03, 0xcf, 0x66, 0x17, 0xc1, 0xd0, 0x67, 0x94, 0xdb, 0x09, 0x49, 0x49, 0xc4, 0x3a, 0x49, 0x1a,
+	0xf3, 0x18, 0xee, 0xe9, 0x68, 0x67, 0x1e, 0x7d, 0xb8, 0xef, 0xc7, 0x7e, 0xac, 0x62, 0xb6, 0xfc,
+	0xd2, 0x32, 0xfc, 0xb3, 0x06, 0xb6, 0x3f, 0xaa, 0x3c, 0x78, 0x02, 0x76, 0x23, 0x32, 0x76, 0xbf,
+	0x91, 0x70, 0x44, 0x5d, 0x16, 0x5c, 0x52, 0xd3, 0x68, 0x19, 0xed, 0x5a, 0xef, 0x30, 0x13, 0x68,
+	0x2d, 0x92, 0x0b, 0x74, 0x7f, 0x42, 0xa2, 0xb0, 0x8b, 0x57, 0x79, 0xec, 0x34, 0x23, 0x32, 0xfe,
+	0x24, 0xf1, 0x69, 0x70, 0x49, 0xe1, 0x7b, 0x20, 0xb1, 0xeb, 0x0d, 0x82, 0xf0, 0x3c, 0xa5, 0x43,
+	0xf3, 0x96, 0x2a, 0xf8, 0x2c, 0x13, 0x68, 0x85, 0xcf, 0x05, 0xba, 0x57, 0x96, 0x9b, 0xb3, 0xd8,
+	0x69, 0x44, 0x64, 0xfc, 0xb6, 0x40, 0x70, 0x04, 0x76, 0x19, 0x8f, 0x53, 0xe2, 0x53, 0xf7, 0xeb,
+	0x28, 0xe6, 0x84, 0x99, 0xd5, 0x56, 0xb5, 0xdd, 0x38, 0x7a, 0xd2, 0x59, 0x3b, 0x69, 0xe7, 0x54,
+	0xcb, 0x4e, 0xa4, 0xaa, 0x67, 0x5f, 0x09, 0x54, 0x91, 0x27, 0x58, 0x4d, 0x2e, 0x4f, 0xb0, 0xca,
+	0x63, 0xe7, 0x0e, 0x5b, 0x4a, 0x67, 0xd0, 0x07, 0x8d, 0xb9, 0xc2, 0x27, 0xcc, 0xac, 0xb5, 0x8c,
+	0x76, 0xe3, 0xe8, 0xd1, 0xa6, 0x9e, 0xef, 0x08, 0xeb, 0x3d, 0x2f, 0x3a, 0x2e, 0xe7, 0xe5, 0x02,
+	0xc1, 0xd5, 0x76, 0xbe, 0xec, 0x05, 0xd8, 0x22, 0x0d, 0x72, 0x70, 0xd0, 0x0f, 0x63, 0xef, 0x8b,
+	0xbb, 0xa4, 0x70, 0xc3, 0x20, 0x0a, 0xb8, 0xb9, 0xa5, 0xc6, 0xf6, 0x2a, 0x13, 0x68, 0x93, 0x24,
+	0x17, 0xc8, 0xd2, 0xf5, 0x37, 0x08, 0xb0, 0xb3, 0xaf, 0x22, 0xa5, 0xcf, 0x0f, 0x92, 0xee, 0xd6,
+	0xfe, 0xff, 0x46, 0x15, 0xfc, 0xc7, 0x00, 0xa0, 0x8c, 0xc0, 0x43, 0x50, 0x4b, 0x29, 0x39, 0x2f,
+	0xee, 0xff, 0x20, 0x13, 0x48, 0xe1, 0x5c, 0xa0, 0x86, 0x6e, 0x22, 0x11, 0x76, 0x14, 0x09, 0x6d,
+	0xb0, 0x75, 0x91, 0x06, 0x9c, 0x16, 0x97, 0xfb, 0x20, 0x13, 0x48, 0x13, 0xb9, 0x40, 0x4d, 0x2d,
+	0x57, 0x10, 0x3b, 0x9a, 0x86, 0x5d, 0x50, 0x4f, 0x68, 0xea, 0xf6, 0x27, 0x9c, 0x9a, 0x55, 0x95,
+	0x83, 0x32, 0x81, 0x16, 0x5c, 0x2e, 0xd0, 0x9e, 0x4e, 0x9b, 0x33, 0xd8, 0xb9, 0x9d, 0xd0, 0xb4,
+	0x37, 0xe1, 0xb4, 0xb0, 0xfb, 0xdd, 0x00, 0xcd, 0xe5, 0x4b, 0x96, 0x86, 0x13, 0xc2, 0x07, 0xca,
+	0xf0, 0x8e, 0x36, 0x2c, 0x71, 0x69, 0x58, 0x22, 0xec, 0x28, 0x12, 0xbe, 0x06, 0x3b, 0xf2, 0x37,
+	0x93, 0x95, 0x59, 0x61, 0xfa, 0x69, 0x26, 0x50, 0x49, 0xe6, 0x02, 0xdd, 0x2d, 0x7f, 0x47, 0x45,
+	0x61, 0xa7, 0x1e, 0x91, 0xb1, 0xb4, 0xc0, 0x0a, 0x0f, 0x3f, 0x4a, 0x0f, 0x67, 0x8c, 0xf8, 0x54,
+	0xce, 0x41, 0x97, 0x34, 0xca, 0x39, 0xcc, 0xcb, 0x15, 0x73, 0x28, 0x4a, 0x69, 0x1a, 0x1e, 0x83,
+	0xfa, 0xda, 0xc3, 0x50, 0x73, 0x58, 0x7a, 0x14, 0xc5, 0x1c, 0xca, 0x07, 0xb1, 0x08, 0x6a, 0x13,
+	0xbd, 0xb3, 0xab, 0xa9, 0x65, 0x5c, 0x4f, 0x2d, 0xe3, 0xdf, 0xd4, 0x32, 0x7e, 0xcd, 0xac, 0xca,
+	0xf5, 0xcc, 0xaa, 0xfc, 0x9d, 0x59, 0x95, 0xcf, 0xc7, 0x7e, 0xc0, 0x07, 0xa3, 0x7e, 0xc7, 0x8b,
+	0x23, 0xfb, 0x8d, 0xde, 0x13, 0x5e, 0xcc, 0xa2, 0xc0, 0x7b, 0xb1, 0x58, 0x17, 0xe3, 0x72, 0x73,
+	0x04, 0x43, 0x4e, 0xd3, 0x21, 0x09, 0x6d, 0x3e, 0x49, 0x28, 0xeb, 0x6f, 0xab, 0xdd, 0xf0, 0xf2,
+	0x66, 0x00, 0x1d, 0x00, 0x36, 0xde, 0x62, 0x04, 0x00, 0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BlockStorageGasLimit != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BlockStorageGasLimit))
+		i--
+		dAtA[i] = 0x28
+	}
+	{
+		size, err := m.StorageGas.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if len(m.StorageQuotas) > 0 {
+		for iNdEx := len(m.StorageQuotas) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StorageQuotas[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintParams(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.MaxChildren != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxChildren))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxValueSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxValueSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageGas) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageGas) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageGas) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PerByte != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PerByte))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Write != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.Write))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Read != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.Read))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageQuota) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageQuota) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageQuota) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Children != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.Children))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Bytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
+	offset -= sovParams(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxValueSize != 0 {
+		n += 1 + sovParams(uint64(m.MaxValueSize))
+	}
+	if m.MaxChildren != 0 {
+		n += 1 + sovParams(uint64(m.MaxChildren))
+	}
+	if len(m.StorageQuotas) > 0 {
+		for _, e := range m.StorageQuotas {
+			l = e.Size()
+			n += 1 + l + sovParams(uint64(l))
+		}
+	}
+	l = m.StorageGas.Size()
+	n += 1 + l + sovParams(uint64(l))
+	if m.BlockStorageGasLimit != 0 {
+		n += 1 + sovParams(uint64(m.BlockStorageGasLimit))
+	}
+	return n
+}
+
+func (m *StorageGas) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Read != 0 {
+		n += 1 + sovParams(uint64(m.Read))
+	}
+	if m.Write != 0 {
+		n += 1 + sovParams(uint64(m.Write))
+	}
+	if m.PerByte != 0 {
+		n += 1 + sovParams(uint64(m.PerByte))
+	}
+	return n
+}
+
+func (m *StorageQuota) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.MaxBytes != 0 {
+		n += 1 + sovParams(uint64(m.MaxBytes))
+	}
+	return n
+}
+
+func (m *StorageUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Bytes != 0 {
+		n += 1 + sovParams(uint64(m.Bytes))
+	}
+	if m.Children != 0 {
+		n += 1 + sovParams(uint64(m.Children))
+	}
+	return n
+}
+
+func sovParams(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozParams(x uint64) (n int) {
+	return sovParams(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxValueSize", wireType)
+			}
+			m.MaxValueSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxValueSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxChildren", wireType)
+			}
+			m.MaxChildren = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxChildren |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageQuotas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageQuotas = append(m.StorageQuotas, StorageQuota{})
+			if err := m.StorageQuotas[len(m.StorageQuotas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageGas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StorageGas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockStorageGasLimit", wireType)
+			}
+			m.BlockStorageGasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockStorageGasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageGas) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageGas: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageGas: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Read", wireType)
+			}
+			m.Read = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Read |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Write", wireType)
+			}
+			m.Write = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Write |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PerByte", wireType)
+			}
+			m.PerByte = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PerByte |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageQuota) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageQuota: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageQuota: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			}
+			m.MaxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+			}
+			m.Children = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Children |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipParams(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthParams
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupParams
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthParams
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthParams        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowParams          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupParams = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,214 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// A storage path is written as its segments separated by ".", where a "\"
+// makes the next character part of the segment.  So "a\.b.c" is the segment
+// "a.b" followed by "c".  The empty path is the root, which has no value.
+
+// PathSegments returns the segments of a storage path.
+func PathSegments(path string) []string {
+	if path == "" {
+		return nil
+	}
+	var segments []string
+	var segment strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\\' && i+1 < len(path):
+			i++
+			segment.WriteByte(path[i])
+		case c == '.':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(c)
+		}
+	}
+	return append(segments, segment.String())
+}
+
+// PathString returns the storage path of segments.
+func PathString(segments []string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = EscapePathSegment(segment)
+	}
+	return strings.Join(escaped, ".")
+}
+
+// EscapePathSegment escapes a segment for use in a storage path.
+func EscapePathSegment(segment string) string {
+	if !strings.ContainsAny(segment, `\.`) {
+		return segment
+	}
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		if c := segment[i]; c == '\\' || c == '.' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(segment[i])
+	}
+	return b.String()
+}
+
+// ValidatePath checks that path is a non-root storage path written the way
+// PathString writes it, so that no two paths name the same entry, and that
+// none of its segments are empty.
+func ValidatePath(path string) error {
+	if path == "" {
+		return fmt.Errorf("the root path cannot have a value")
+	}
+	if !utf8.ValidString(path) {
+		return fmt.Errorf("path is not UTF-8")
+	}
+	segments := PathSegments(path)
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("path has an empty segment")
+		}
+	}
+	if canonical := PathString(segments); canonical != path {
+		return fmt.Errorf("path should be written %q", canonical)
+	}
+	return nil
+}
+
+// SplitPath returns the parent path and last segment of a storage path.
+func SplitPath(path string) (parent, key string) {
+	segments := PathSegments(path)
+	if len(segments) == 0 {
+		return "", ""
+	}
+	last := len(segments) - 1
+	return PathString(segments[:last]), segments[last]
+}
+
+// JoinPath returns the storage path of the segment key under parent.
+func JoinPath(parent, key string) string {
+	if parent == "" {
+		return EscapePathSegment(key)
+	}
+	return parent + "." + EscapePathSegment(key)
+}
+
+// PathAncestors returns the root, each ancestor of path, and path itself.
+func PathAncestors(path string) []string {
+	segments := PathSegments(path)
+	ancestors := make([]string, len(segments)+1)
+	for i := range segments {
+		ancestors[i+1] = PathString(segments[:i+1])
+	}
+	return ancestors
+}
+
+// In the store, each segment of a path is escaped and terminated by
+// segmentEnd, so that the encoding of a path is a prefix of exactly the
+// encodings of the paths at and below it.
+const (
+	segmentEnd    = 0x00
+	segmentEscape = 0x01
+)
+
+// EncodePath returns the store encoding of a storage path.
+func EncodePath(path string) []byte {
+	var bz []byte
+	for _, segment := range PathSegments(path) {
+		for i := 0; i < len(segment); i++ {
+			switch c := segment[i]; c {
+			case segmentEnd, segmentEscape:
+				bz = append(bz, segmentEscape, c+1)
+			default:
+				bz = append(bz, c)
+			}
+		}
+		bz = append(bz, segmentEnd)
+	}
+	return bz
+}
+
+// DecodePath returns the storage path of a store encoding.
+func DecodePath(bz []byte) (string, error) {
+	segments, err := decodeSegments(bz)
+	if err != nil {
+		return "", err
+	}
+	return PathString(segments), nil
+}
+
+func decodeSegments(bz []byte) ([]string, error) {
+	var segments []string
+	var segment []byte
+	for i := 0; i < len(bz); i++ {
+		switch c := bz[i]; c {
+		case segmentEnd:
+			segments = append(segments, string(segment))
+			segment = segment[:0]
+		case segmentEscape:
+			if i+1 >= len(bz) || (bz[i+1] != segmentEnd+1 && bz[i+1] != segmentEscape+1) {
+				return nil, fmt.Errorf("malformed path encoding %q", bz)
+			}
+			i++
+			segment = append(segment, bz[i]-1)
+		default:
+			segment = append(segment, c)
+		}
+	}
+	if len(segment) > 0 {
+		return nil, fmt.Errorf("unterminated path encoding %q", bz)
+	}
+	return segments, nil
+}
+
+// DepthKey is the encoding of path after its number of segments, so that
+// the paths at one depth below a parent share a prefix, and the root is not
+// an empty key.
+func DepthKey(path string) []byte {
+	depth := len(PathSegments(path))
+	bz := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(bz, uint64(depth))
+	return append(bz[:n], EncodePath(path)...)
+}
+
+// DecodeDepthKey returns the storage path of a DepthKey.
+func DecodeDepthKey(bz []byte) (string, error) {
+	depth, n := binary.Uvarint(bz)
+	if n <= 0 {
+		return "", fmt.Errorf("malformed depth key %q", bz)
+	}
+	segments, err := decodeSegments(bz[n:])
+	if err != nil {
+		return "", err
+	}
+	if uint64(len(segments)) != depth {
+		return "", fmt.Errorf("depth key %q has %d segments, not %d", bz, len(segments), depth)
+	}
+	return PathString(segments), nil
+}
+
+// ChildrenPrefix is the common prefix of the DepthKey of each child of
+// parent.
+func ChildrenPrefix(parent string) []byte {
+	depth := len(PathSegments(parent)) + 1
+	bz := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(bz, uint64(depth))
+	return append(bz[:n], EncodePath(parent)...)
+}
+
+// DecodeChildKey returns the last segment of a child's DepthKey, given
+// without its ChildrenPrefix.
+func DecodeChildKey(bz []byte) (string, error) {
+	segments, err := decodeSegments(bz)
+	if err != nil {
+		return "", err
+	}
+	if len(segments) != 1 {
+		return "", fmt.Errorf("malformed child key %q", bz)
+	}
+	return segments[0], nil
+}
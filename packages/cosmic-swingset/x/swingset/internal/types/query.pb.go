@@ -312,6 +312,234 @@ func (m *QueryStorageKeysResponse) GetPagination() *query.PageResponse {
 	return nil
 }
 
+type QueryStorageEntriesRequest struct {
+	Path       []string           `protobuf:"bytes,1,rep,name=path,proto3" json:"path" yaml:"path"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryStorageEntriesRequest) Reset()         { *m = QueryStorageEntriesRequest{} }
+func (m *QueryStorageEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryStorageEntriesRequest) ProtoMessage()    {}
+func (*QueryStorageEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_76266f656a1a9971, []int{6}
+}
+func (m *QueryStorageEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryStorageEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryStorageEntriesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryStorageEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStorageEntriesRequest.Merge(m, src)
+}
+func (m *QueryStorageEntriesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryStorageEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStorageEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStorageEntriesRequest proto.InternalMessageInfo
+
+func (m *QueryStorageEntriesRequest) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *QueryStorageEntriesRequest) GetPagination() *query.PageRequest {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type QueryStorageEntriesResponse struct {
+	Entries    []StorageEntry      `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries" yaml:"entries"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryStorageEntriesResponse) Reset()         { *m = QueryStorageEntriesResponse{} }
+func (m *QueryStorageEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryStorageEntriesResponse) ProtoMessage()    {}
+func (*QueryStorageEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_76266f656a1a9971, []int{7}
+}
+func (m *QueryStorageEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryStorageEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryStorageEntriesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryStorageEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStorageEntriesResponse.Merge(m, src)
+}
+func (m *QueryStorageEntriesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryStorageEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStorageEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStorageEntriesResponse proto.InternalMessageInfo
+
+func (m *QueryStorageEntriesResponse) GetEntries() []StorageEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *QueryStorageEntriesResponse) GetPagination() *query.PageResponse {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type QueryParamsRequest struct {
+}
+
+func (m *QueryParamsRequest) Reset()         { *m = QueryParamsRequest{} }
+func (m *QueryParamsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryParamsRequest) ProtoMessage()    {}
+func (*QueryParamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_76266f656a1a9971, []int{8}
+}
+func (m *QueryParamsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryParamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryParamsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryParamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryParamsRequest.Merge(m, src)
+}
+func (m *QueryParamsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryParamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryParamsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryParamsRequest proto.InternalMessageInfo
+
+type QueryParamsResponse struct {
+	Params Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params" yaml:"params"`
+}
+
+func (m *QueryParamsResponse) Reset()         { *m = QueryParamsResponse{} }
+func (m *QueryParamsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryParamsResponse) ProtoMessage()    {}
+func (*QueryParamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_76266f656a1a9971, []int{9}
+}
+func (m *QueryParamsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryParamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryParamsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryParamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryParamsResponse.Merge(m, src)
+}
+func (m *QueryParamsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryParamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryParamsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryParamsResponse proto.InternalMessageInfo
+
+func (m *QueryParamsResponse) GetParams() Params {
+	if m != nil {
+		return m.Params
+	}
+	return Params{}
+}
+
+type QueryStorageUsageRequest struct {
+	Path []string `protobuf:"bytes,1,rep,name=path,proto3" json:"path" yaml:"path"`
+}
+
+func (m *QueryStorageUsageRequest) Reset()         { *m = QueryStorageUsageRequest{} }
+func (m *QueryStorageUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryStorageUsageRequest) ProtoMessage()    {}
+func (*QueryStorageUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_76266f656a1a9971, []int{10}
+}
+func (m *QueryStorageUsageRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryStorageUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryStorageUsageRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryStorageUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStorageUsageRequest.Merge(m, src)
+}
+func (m *QueryStorageUsageRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryStorageUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStorageUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStorageUsageRequest proto.InternalMessageInfo
+
+func (m *QueryStorageUsageRequest) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QueryEgressRequest)(nil), "agoric.swingset.QueryEgressRequest")
 	proto.RegisterType((*QueryMailboxRequest)(nil), "agoric.swingset.QueryMailboxRequest")
@@ -319,51 +547,69 @@ func init() {
 	proto.RegisterType((*QueryStorageResponse)(nil), "agoric.swingset.QueryStorageResponse")
 	proto.RegisterType((*QueryStorageKeysRequest)(nil), "agoric.swingset.QueryStorageKeysRequest")
 	proto.RegisterType((*QueryStorageKeysResponse)(nil), "agoric.swingset.QueryStorageKeysResponse")
+	proto.RegisterType((*QueryStorageEntriesRequest)(nil), "agoric.swingset.QueryStorageEntriesRequest")
+	proto.RegisterType((*QueryStorageEntriesResponse)(nil), "agoric.swingset.QueryStorageEntriesResponse")
+	proto.RegisterType((*QueryParamsRequest)(nil), "agoric.swingset.QueryParamsRequest")
+	proto.RegisterType((*QueryParamsResponse)(nil), "agoric.swingset.QueryParamsResponse")
+	proto.RegisterType((*QueryStorageUsageRequest)(nil), "agoric.swingset.QueryStorageUsageRequest")
 }
 
 func init() { proto.RegisterFile("agoric/swingset/query.proto", fileDescriptor_76266f656a1a9971) }
 
 var fileDescriptor_76266f656a1a9971 = []byte{
-	// 611 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x94, 0x3f, 0x6f, 0x13, 0x31,
-	0x18, 0xc6, 0xeb, 0x92, 0xb6, 0xaa, 0x5b, 0x09, 0xc9, 0x54, 0x4a, 0x08, 0x70, 0x89, 0x0c, 0xb4,
-	0x29, 0x25, 0xb6, 0x5a, 0x98, 0x60, 0x4a, 0x24, 0x28, 0x12, 0x42, 0x82, 0x20, 0x16, 0xc4, 0xe2,
-	0x5c, 0xac, 0xcb, 0xa9, 0xc9, 0xf9, 0x7a, 0x76, 0x42, 0xa3, 0xaa, 0x0b, 0x0b, 0x0b, 0x42, 0x45,
-	0x7c, 0x0d, 0x3e, 0x08, 0x63, 0x25, 0x16, 0xa6, 0x08, 0x25, 0x4c, 0x1d, 0x3b, 0x32, 0x21, 0xdb,
-	0x17, 0x72, 0xf9, 0x07, 0x85, 0x81, 0x29, 0x79, 0xfd, 0xbe, 0xf7, 0x3c, 0xbf, 0xf3, 0x3d, 0x36,
-	0xbc, 0xc2, 0x3c, 0x11, 0xf9, 0x2e, 0x95, 0xaf, 0xfd, 0xc0, 0x93, 0x5c, 0xd1, 0xfd, 0x16, 0x8f,
-	0x3a, 0x24, 0x8c, 0x84, 0x12, 0xe8, 0xa2, 0x6d, 0x92, 0x41, 0x33, 0xbb, 0xe6, 0x09, 0x4f, 0x98,
-	0x1e, 0xd5, 0xff, 0xec, 0x58, 0xf6, 0xda, 0xb8, 0x86, 0x54, 0x22, 0x62, 0x1e, 0x8f, 0xdb, 0xb7,
-	0x5c, 0x21, 0x9b, 0x42, 0xd2, 0x2a, 0x93, 0xdc, 0xca, 0xd3, 0xf6, 0x76, 0x95, 0x2b, 0xb6, 0x4d,
-	0x43, 0xe6, 0xf9, 0x01, 0x53, 0xbe, 0x08, 0xe2, 0xd9, 0xab, 0x9e, 0x10, 0x5e, 0x83, 0x53, 0x16,
-	0xfa, 0x94, 0x05, 0x81, 0x50, 0xa6, 0x29, 0x6d, 0x17, 0x47, 0x10, 0x3d, 0xd3, 0xcf, 0x3f, 0xf0,
-	0x22, 0x2e, 0x65, 0x85, 0xef, 0xb7, 0xb8, 0x54, 0xe8, 0x15, 0x4c, 0x85, 0x9c, 0x47, 0x19, 0x90,
-	0x07, 0x85, 0xd5, 0xf2, 0xa3, 0xd3, 0x6e, 0xce, 0xd4, 0x67, 0xdd, 0xdc, 0x4a, 0x87, 0x35, 0x1b,
-	0xf7, 0xb0, 0xae, 0xf0, 0x8f, 0x6e, 0xae, 0xe8, 0xf9, 0xaa, 0xde, 0xaa, 0x12, 0x57, 0x34, 0x69,
-	0xcc, 0x64, 0x7f, 0x8a, 0xb2, 0xb6, 0x47, 0x55, 0x27, 0xe4, 0x92, 0x94, 0x5c, 0xb7, 0x54, 0xab,
-	0x19, 0x79, 0xa3, 0x82, 0x25, 0xbc, 0x64, 0x3c, 0x9f, 0x30, 0xbf, 0x51, 0x15, 0x07, 0xff, 0xc7,
-	0xb4, 0x1c, 0x9b, 0x3e, 0xb7, 0x1b, 0x39, 0x30, 0xdd, 0x82, 0xa9, 0x90, 0xa9, 0x7a, 0x06, 0xe4,
-	0x2f, 0x14, 0x96, 0xcb, 0x69, 0x63, 0xca, 0x54, 0x3d, 0x61, 0xca, 0x54, 0x1d, 0x57, 0xcc, 0x22,
-	0xde, 0x85, 0x6b, 0xa3, 0x1a, 0x32, 0x14, 0x81, 0xe4, 0x88, 0xc2, 0x85, 0x36, 0x6b, 0xb4, 0xb8,
-	0x41, 0x5f, 0x2e, 0x5f, 0x3e, 0xed, 0xe6, 0xec, 0xc2, 0x59, 0x37, 0xb7, 0x6a, 0x65, 0x4c, 0x89,
-	0x2b, 0x76, 0x19, 0xbf, 0x07, 0x30, 0x9d, 0x54, 0x7a, 0xcc, 0x3b, 0xf2, 0x5f, 0x88, 0xd0, 0x43,
-	0x08, 0x87, 0x1f, 0x3c, 0x33, 0x9f, 0x07, 0x85, 0x95, 0x9d, 0x75, 0x62, 0x77, 0x83, 0xe8, 0x74,
-	0x10, 0x1b, 0xbe, 0x38, 0x1d, 0xe4, 0xe9, 0xf0, 0xd5, 0x2b, 0x89, 0x27, 0xf1, 0x31, 0x80, 0x99,
-	0x49, 0xa0, 0xf8, 0xf5, 0xb6, 0x60, 0x6a, 0x8f, 0x77, 0x64, 0x92, 0x48, 0xd7, 0x43, 0x22, 0x5d,
-	0xe1, 0x8a, 0x59, 0x44, 0xbb, 0x53, 0x88, 0x36, 0xfe, 0x48, 0x64, 0x9d, 0x92, 0x48, 0x3b, 0x9f,
-	0x52, 0x70, 0xc1, 0x20, 0xa1, 0x36, 0x5c, 0xb4, 0xf1, 0x44, 0xd7, 0xc9, 0xd8, 0xf1, 0x21, 0x93,
-	0xe1, 0xcd, 0xa6, 0x27, 0x86, 0x6c, 0x1f, 0x93, 0x37, 0x5f, 0xbe, 0x7f, 0x9c, 0x2f, 0xa0, 0x75,
-	0x3a, 0x7e, 0xba, 0x06, 0x87, 0x87, 0x9b, 0x41, 0x7a, 0xa8, 0x13, 0x73, 0x84, 0xde, 0x02, 0xb8,
-	0x14, 0x67, 0x14, 0xdd, 0x98, 0xee, 0x3c, 0x1a, 0xe1, 0xec, 0xcd, 0xe9, 0x53, 0x63, 0x79, 0xc1,
-	0xd4, 0x80, 0x6c, 0xa2, 0x8d, 0x99, 0x20, 0x4d, 0xab, 0x3b, 0x20, 0x79, 0x07, 0xe0, 0x52, 0x2c,
-	0x32, 0x8b, 0x64, 0x34, 0xd7, 0xe7, 0x25, 0xb9, 0x6b, 0x48, 0x08, 0xba, 0x3d, 0x93, 0x24, 0xbe,
-	0x78, 0x68, 0x8d, 0x29, 0x46, 0x0f, 0x75, 0xe8, 0x8e, 0xd0, 0x07, 0x00, 0x53, 0x3a, 0x21, 0xa8,
-	0xf0, 0x5b, 0x97, 0x44, 0xaa, 0xb3, 0x9b, 0xe7, 0x98, 0xfc, 0x6b, 0x26, 0x1d, 0xb8, 0x98, 0xa9,
-	0xfc, 0xe2, 0x73, 0xcf, 0x01, 0x27, 0x3d, 0x07, 0x7c, 0xeb, 0x39, 0xe0, 0xb8, 0xef, 0xcc, 0x9d,
-	0xf4, 0x9d, 0xb9, 0xaf, 0x7d, 0x67, 0xee, 0xe5, 0xfd, 0xc4, 0x75, 0x51, 0xb2, 0x8a, 0x3a, 0x8e,
-	0xbe, 0x5b, 0xfc, 0x25, 0x7c, 0x30, 0xf4, 0xf0, 0x03, 0xc5, 0xa3, 0x80, 0x35, 0xec, 0x3d, 0x52,
-	0x5d, 0x34, 0xd7, 0xe4, 0x9d, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb7, 0x3e, 0x0c, 0x8c, 0xd5,
-	0x05, 0x00, 0x00,
+	// 827 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x96, 0xcf, 0x4f, 0xe3, 0x46,
+	0x14, 0xc7, 0x33, 0x34, 0x24, 0x62, 0x42, 0x5b, 0x69, 0x40, 0x22, 0x35, 0x25, 0x86, 0x29, 0x85,
+	0x50, 0x88, 0x47, 0xa4, 0xad, 0x2a, 0xb5, 0x27, 0x22, 0x51, 0x2a, 0x55, 0x95, 0x68, 0x2a, 0x2e,
+	0xa8, 0x97, 0x49, 0x32, 0x72, 0x2c, 0x12, 0xdb, 0x78, 0x1c, 0x4a, 0x44, 0xb9, 0xf4, 0xd2, 0x1e,
+	0xaa, 0x0a, 0xd4, 0x73, 0xff, 0x99, 0x9e, 0x38, 0x22, 0xad, 0x56, 0xda, 0x93, 0xb5, 0x82, 0x3d,
+	0x71, 0xcc, 0x71, 0x4f, 0x2b, 0xcf, 0x8c, 0x37, 0x76, 0x7e, 0xc1, 0xa2, 0xd5, 0xee, 0x09, 0x66,
+	0xde, 0x9b, 0xf7, 0xfd, 0xcc, 0x8f, 0xf7, 0x8d, 0xe1, 0x22, 0x35, 0x1d, 0xcf, 0xaa, 0x13, 0xfe,
+	0x9b, 0x65, 0x9b, 0x9c, 0xf9, 0xe4, 0xb8, 0xc3, 0xbc, 0xae, 0xe1, 0x7a, 0x8e, 0xef, 0xa0, 0x8f,
+	0x65, 0xd0, 0x88, 0x82, 0xda, 0xbc, 0xe9, 0x98, 0x8e, 0x88, 0x91, 0xf0, 0x3f, 0x99, 0xa6, 0x7d,
+	0x3a, 0x58, 0xc3, 0xa5, 0x1e, 0x6d, 0x73, 0x15, 0x5d, 0x1a, 0x8c, 0x72, 0xdf, 0xf1, 0xa8, 0xc9,
+	0x54, 0xf8, 0x8b, 0xba, 0xc3, 0xdb, 0x0e, 0x27, 0x35, 0xca, 0x99, 0x14, 0x27, 0x27, 0xdb, 0x35,
+	0xe6, 0xd3, 0x6d, 0xe2, 0x52, 0xd3, 0xb2, 0xa9, 0x6f, 0x39, 0x76, 0x24, 0x64, 0x3a, 0x8e, 0xd9,
+	0x62, 0x84, 0xba, 0x16, 0xa1, 0xb6, 0xed, 0xf8, 0x22, 0xa8, 0x84, 0xb0, 0x07, 0xd1, 0xcf, 0xe1,
+	0xfa, 0x5d, 0xd3, 0x63, 0x9c, 0x57, 0xd9, 0x71, 0x87, 0x71, 0x1f, 0xfd, 0x0a, 0xd3, 0x2e, 0x63,
+	0x5e, 0x1e, 0x2c, 0x83, 0xe2, 0x6c, 0xe5, 0x87, 0xbb, 0x40, 0x17, 0xe3, 0x5e, 0xa0, 0xe7, 0xba,
+	0xb4, 0xdd, 0xfa, 0x16, 0x87, 0x23, 0xfc, 0x32, 0xd0, 0x4b, 0xa6, 0xe5, 0x37, 0x3b, 0x35, 0xa3,
+	0xee, 0xb4, 0x89, 0x62, 0x92, 0x7f, 0x4a, 0xbc, 0x71, 0x44, 0xfc, 0xae, 0xcb, 0xb8, 0xb1, 0x53,
+	0xaf, 0xef, 0x34, 0x1a, 0xa2, 0xbc, 0xa8, 0x82, 0x39, 0x9c, 0x13, 0x9a, 0x3f, 0x51, 0xab, 0x55,
+	0x73, 0x4e, 0xdf, 0x8d, 0x68, 0x45, 0x89, 0xfe, 0x22, 0x0f, 0x32, 0x12, 0xdd, 0x84, 0x69, 0x97,
+	0xfa, 0xcd, 0x3c, 0x58, 0xfe, 0xa0, 0x38, 0x53, 0x59, 0x10, 0xa2, 0xd4, 0x6f, 0xc6, 0x44, 0xa9,
+	0xdf, 0xc4, 0x55, 0x31, 0x89, 0xf7, 0xe0, 0x7c, 0xb2, 0x06, 0x77, 0x1d, 0x9b, 0x33, 0x44, 0xe0,
+	0xf4, 0x09, 0x6d, 0x75, 0x98, 0x40, 0x9f, 0xa9, 0x7c, 0x72, 0x17, 0xe8, 0x72, 0xa2, 0x17, 0xe8,
+	0xb3, 0xb2, 0x8c, 0x18, 0xe2, 0xaa, 0x9c, 0xc6, 0xff, 0x00, 0xb8, 0x10, 0xaf, 0xf4, 0x23, 0xeb,
+	0xf2, 0xc7, 0x10, 0xa1, 0xef, 0x21, 0xec, 0x5f, 0x78, 0x7e, 0x6a, 0x19, 0x14, 0x73, 0xe5, 0x35,
+	0x43, 0x9e, 0x86, 0x11, 0xbe, 0x0e, 0x43, 0x3e, 0x4d, 0xf5, 0x3a, 0x8c, 0xfd, 0xfe, 0xd6, 0xab,
+	0xb1, 0x95, 0xf8, 0x02, 0xc0, 0xfc, 0x30, 0x90, 0xda, 0xde, 0x26, 0x4c, 0x1f, 0xb1, 0x2e, 0x8f,
+	0x13, 0x85, 0xe3, 0x3e, 0x51, 0x38, 0xc2, 0x55, 0x31, 0x89, 0xf6, 0x46, 0x10, 0xad, 0xdf, 0x4b,
+	0x24, 0x95, 0x12, 0x48, 0x97, 0x00, 0x6a, 0x71, 0xa4, 0x5d, 0xdb, 0xf7, 0x2c, 0xf6, 0x7e, 0x8f,
+	0xe9, 0x7f, 0x00, 0x17, 0x47, 0x32, 0xa9, 0x93, 0x3a, 0x84, 0x59, 0x26, 0xa7, 0x04, 0x57, 0xae,
+	0xbc, 0x64, 0x0c, 0xb8, 0x81, 0x11, 0x5b, 0xd9, 0xad, 0xac, 0x5c, 0x05, 0x7a, 0xea, 0x2e, 0xd0,
+	0xa3, 0x55, 0xbd, 0x40, 0xff, 0x48, 0xd2, 0xab, 0x09, 0x5c, 0x8d, 0x42, 0x6f, 0xef, 0x60, 0xe7,
+	0x55, 0xcb, 0xef, 0x0b, 0xc3, 0x51, 0xdb, 0xc4, 0x26, 0x9c, 0x4b, 0xcc, 0xaa, 0x1d, 0xed, 0xc3,
+	0x8c, 0x34, 0x26, 0xf1, 0xb6, 0x73, 0xe5, 0x85, 0xa1, 0x0d, 0xc9, 0x05, 0x15, 0x5d, 0x6d, 0x45,
+	0xa5, 0xf7, 0x02, 0xfd, 0xc3, 0xe8, 0x1e, 0xc2, 0x31, 0xae, 0xaa, 0x00, 0xde, 0x4b, 0xbe, 0xb4,
+	0x03, 0xfe, 0xc8, 0x6e, 0x2c, 0x3f, 0xcd, 0xc2, 0x69, 0x51, 0x09, 0x9d, 0xc0, 0x8c, 0xf4, 0x2f,
+	0xf4, 0xd9, 0x10, 0xde, 0xb0, 0xbb, 0x69, 0xc3, 0x7b, 0x90, 0x71, 0x6c, 0xfc, 0xf1, 0xe4, 0xc5,
+	0xbf, 0x53, 0x45, 0xb4, 0x46, 0x06, 0xed, 0x37, 0x72, 0x57, 0x26, 0x12, 0xc9, 0x59, 0x68, 0x29,
+	0xe7, 0xe8, 0x4f, 0x00, 0xb3, 0xca, 0xc4, 0xd0, 0xea, 0x68, 0xe5, 0xa4, 0xc7, 0x69, 0x9f, 0x8f,
+	0xce, 0x1a, 0x30, 0x14, 0x4c, 0x04, 0xc8, 0x06, 0x5a, 0x1f, 0x0b, 0xd2, 0x96, 0x75, 0x23, 0x92,
+	0xbf, 0x01, 0xcc, 0xaa, 0x22, 0xe3, 0x48, 0x92, 0xc6, 0xf7, 0x50, 0x92, 0xaf, 0x04, 0x89, 0x81,
+	0xb6, 0xc6, 0x92, 0xa8, 0x5f, 0x26, 0xd2, 0xa0, 0x3e, 0x25, 0x67, 0xe1, 0xcd, 0x9c, 0xa3, 0x4b,
+	0x00, 0xd3, 0xa1, 0x85, 0xa0, 0xe2, 0x44, 0x95, 0x98, 0xed, 0x69, 0x1b, 0x0f, 0xc8, 0x7c, 0x63,
+	0xa6, 0xd0, 0x91, 0x22, 0xa6, 0xff, 0x00, 0xcc, 0xaa, 0x7e, 0x45, 0x9b, 0x13, 0xc5, 0x92, 0x4e,
+	0xa3, 0x6d, 0x3d, 0x2c, 0x59, 0xc1, 0x7d, 0x23, 0xe0, 0xb6, 0x11, 0xb9, 0x17, 0x4e, 0x35, 0x76,
+	0xc4, 0xf7, 0x3b, 0xcc, 0xc8, 0x56, 0x1a, 0xf7, 0x88, 0x13, 0xfd, 0xaa, 0xad, 0x4e, 0x4e, 0x52,
+	0x34, 0xeb, 0x82, 0x66, 0x05, 0xe9, 0x63, 0x69, 0x64, 0x57, 0xa2, 0xbf, 0x00, 0x9c, 0x16, 0xad,
+	0x88, 0x26, 0x5f, 0x44, 0xbc, 0x5d, 0xb5, 0xb1, 0xee, 0x26, 0xb2, 0xf0, 0xd7, 0x42, 0x9c, 0xa0,
+	0xd2, 0xbd, 0x47, 0xd1, 0x09, 0xf3, 0xd5, 0x41, 0x54, 0x0e, 0xae, 0x6e, 0x0a, 0xe0, 0xfa, 0xa6,
+	0x00, 0x9e, 0xdf, 0x14, 0xc0, 0xc5, 0x6d, 0x21, 0x75, 0x7d, 0x5b, 0x48, 0x3d, 0xbb, 0x2d, 0xa4,
+	0x0e, 0xbf, 0x8b, 0xfd, 0xf0, 0xef, 0xc8, 0x92, 0xa1, 0xff, 0x59, 0xf5, 0xd2, 0xeb, 0xca, 0xa7,
+	0x7d, 0x11, 0xcb, 0xf6, 0x99, 0x67, 0xd3, 0x96, 0xfc, 0x22, 0xa8, 0x65, 0xc4, 0x07, 0xcf, 0x97,
+	0xaf, 0x06, 0x00, 0xf3, 0x91, 0x66, 0xf9, 0xbd, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -383,6 +629,13 @@ type QueryClient interface {
 	Mailbox(ctx context.Context, in *QueryMailboxRequest, opts ...grpc.CallOption) (*QueryStorageResponse, error)
 	Storage(ctx context.Context, in *QueryStorageRequest, opts ...grpc.CallOption) (*QueryStorageResponse, error)
 	Keys(ctx context.Context, in *QueryStorageKeysRequest, opts ...grpc.CallOption) (*QueryStorageKeysResponse, error)
+	// Entries queries the child keys of a storage path together with their
+	// values.
+	Entries(ctx context.Context, in *QueryStorageEntriesRequest, opts ...grpc.CallOption) (*QueryStorageEntriesResponse, error)
+	// Params queries the swingset module parameters.
+	Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error)
+	// Usage queries the storage used at and below a path.
+	Usage(ctx context.Context, in *QueryStorageUsageRequest, opts ...grpc.CallOption) (*StorageUsage, error)
 }
 
 type queryClient struct {
@@ -429,6 +682,33 @@ func (c *queryClient) Keys(ctx context.Context, in *QueryStorageKeysRequest, opt
 	return out, nil
 }
 
+func (c *queryClient) Entries(ctx context.Context, in *QueryStorageEntriesRequest, opts ...grpc.CallOption) (*QueryStorageEntriesResponse, error) {
+	out := new(QueryStorageEntriesResponse)
+	err := c.cc.Invoke(ctx, "/agoric.swingset.Query/Entries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error) {
+	out := new(QueryParamsResponse)
+	err := c.cc.Invoke(ctx, "/agoric.swingset.Query/Params", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Usage(ctx context.Context, in *QueryStorageUsageRequest, opts ...grpc.CallOption) (*StorageUsage, error) {
+	out := new(StorageUsage)
+	err := c.cc.Invoke(ctx, "/agoric.swingset.Query/Usage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// Egress queries a provisioned egress.
@@ -436,6 +716,13 @@ type QueryServer interface {
 	Mailbox(context.Context, *QueryMailboxRequest) (*QueryStorageResponse, error)
 	Storage(context.Context, *QueryStorageRequest) (*QueryStorageResponse, error)
 	Keys(context.Context, *QueryStorageKeysRequest) (*QueryStorageKeysResponse, error)
+	// Entries queries the child keys of a storage path together with their
+	// values.
+	Entries(context.Context, *QueryStorageEntriesRequest) (*QueryStorageEntriesResponse, error)
+	// Params queries the swingset module parameters.
+	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
+	// Usage queries the storage used at and below a path.
+	Usage(context.Context, *QueryStorageUsageRequest) (*StorageUsage, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -454,6 +741,15 @@ func (*UnimplementedQueryServer) Storage(ctx context.Context, req *QueryStorageR
 func (*UnimplementedQueryServer) Keys(ctx context.Context, req *QueryStorageKeysRequest) (*QueryStorageKeysResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Keys not implemented")
 }
+func (*UnimplementedQueryServer) Entries(ctx context.Context, req *QueryStorageEntriesRequest) (*QueryStorageEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Entries not implemented")
+}
+func (*UnimplementedQueryServer) Params(ctx context.Context, req *QueryParamsRequest) (*QueryParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Params not implemented")
+}
+func (*UnimplementedQueryServer) Usage(ctx context.Context, req *QueryStorageUsageRequest) (*StorageUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Usage not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -531,6 +827,60 @@ func _Query_Keys_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_Entries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStorageEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Entries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agoric.swingset.Query/Entries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Entries(ctx, req.(*QueryStorageEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Params_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Params(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agoric.swingset.Query/Params",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Params(ctx, req.(*QueryParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agoric.swingset.Query/Usage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Usage(ctx, req.(*QueryStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "agoric.swingset.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -551,6 +901,18 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Keys",
 			Handler:    _Query_Keys_Handler,
 		},
+		{
+			MethodName: "Entries",
+			Handler:    _Query_Entries_Handler,
+		},
+		{
+			MethodName: "Params",
+			Handler:    _Query_Params_Handler,
+		},
+		{
+			MethodName: "Usage",
+			Handler:    _Query_Usage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "agoric/swingset/query.proto",
@@ -766,51 +1128,232 @@ func (m *QueryStorageKeysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
-	offset -= sovQuery(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *QueryEgressRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Peer)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
+func (m *QueryStorageEntriesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *QueryMailboxRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Peer)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	return n
+func (m *QueryStorageEntriesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *QueryStorageRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *QueryStorageEntriesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Path) > 0 {
-		for _, s := range m.Path {
+	if m.Pagination != nil {
+		{
+			size, err := m.Pagination.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		for iNdEx := len(m.Path) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Path[iNdEx])
+			copy(dAtA[i:], m.Path[iNdEx])
+			i = encodeVarintQuery(dAtA, i, uint64(len(m.Path[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryStorageEntriesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStorageEntriesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStorageEntriesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pagination != nil {
+		{
+			size, err := m.Pagination.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryParamsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryParamsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryParamsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryParamsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryParamsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryParamsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryStorageUsageRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStorageUsageRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStorageUsageRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for iNdEx := len(m.Path) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Path[iNdEx])
+			copy(dAtA[i:], m.Path[iNdEx])
+			i = encodeVarintQuery(dAtA, i, uint64(len(m.Path[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryEgressRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Peer)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryMailboxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Peer)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryStorageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for _, s := range m.Path {
 			l = len(s)
 			n += 1 + l + sovQuery(uint64(l))
 		}
@@ -869,6 +1412,79 @@ func (m *QueryStorageKeysResponse) Size() (n int) {
 	return n
 }
 
+func (m *QueryStorageEntriesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for _, s := range m.Path {
+			l = len(s)
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryStorageEntriesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryParamsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *QueryParamsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QueryStorageUsageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for _, s := range m.Path {
+			l = len(s)
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovQuery(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1461,6 +2077,474 @@ func (m *QueryStorageKeysResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QueryStorageEntriesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStorageEntriesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStorageEntriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = append(m.Path, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pagination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pagination == nil {
+				m.Pagination = &query.PageRequest{}
+			}
+			if err := m.Pagination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStorageEntriesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStorageEntriesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStorageEntriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, StorageEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pagination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pagination == nil {
+				m.Pagination = &query.PageResponse{}
+			}
+			if err := m.Pagination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryParamsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryParamsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryParamsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryParamsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryParamsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStorageUsageRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStorageUsageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStorageUsageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = append(m.Path, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -112,6 +112,59 @@ func (m *Keys) GetKeys() []string {
 	return nil
 }
 
+// StorageEntry is a storage key and its value.
+type StorageEntry struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key" yaml:"key"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value" yaml:"value"`
+}
+
+func (m *StorageEntry) Reset()         { *m = StorageEntry{} }
+func (m *StorageEntry) String() string { return proto.CompactTextString(m) }
+func (*StorageEntry) ProtoMessage()    {}
+func (*StorageEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e196a9f45e310a8, []int{2}
+}
+func (m *StorageEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageEntry.Merge(m, src)
+}
+func (m *StorageEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageEntry proto.InternalMessageInfo
+
+func (m *StorageEntry) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StorageEntry) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 type Egress struct {
 	Nickname   string                                        `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname" yaml:"nickname"`
 	Peer       github_com_cosmos_cosmos_sdk_types.AccAddress `protobuf:"bytes,2,opt,name=peer,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"peer" yaml:"peer"`
@@ -122,7 +175,7 @@ func (m *Egress) Reset()         { *m = Egress{} }
 func (m *Egress) String() string { return proto.CompactTextString(m) }
 func (*Egress) ProtoMessage()    {}
 func (*Egress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4e196a9f45e310a8, []int{2}
+	return fileDescriptor_4e196a9f45e310a8, []int{3}
 }
 func (m *Egress) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -175,38 +228,40 @@ func (m *Egress) GetPowerFlags() []string {
 func init() {
 	proto.RegisterType((*Storage)(nil), "agoric.swingset.Storage")
 	proto.RegisterType((*Keys)(nil), "agoric.swingset.Keys")
+	proto.RegisterType((*StorageEntry)(nil), "agoric.swingset.StorageEntry")
 	proto.RegisterType((*Egress)(nil), "agoric.swingset.Egress")
 }
 
 func init() { proto.RegisterFile("agoric/swingset/storage.proto", fileDescriptor_4e196a9f45e310a8) }
 
 var fileDescriptor_4e196a9f45e310a8 = []byte{
-	// 387 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0x41, 0x8b, 0x9b, 0x40,
-	0x14, 0xc7, 0x9d, 0xc6, 0xa6, 0xcd, 0x24, 0x10, 0x2a, 0x85, 0xda, 0x42, 0x9d, 0x30, 0xa7, 0x40,
-	0x89, 0x52, 0x7a, 0x6a, 0x72, 0x69, 0x84, 0x96, 0x42, 0x2f, 0xc5, 0xd2, 0x4b, 0x29, 0x2c, 0xc6,
-	0xcc, 0xce, 0x4a, 0xd4, 0x11, 0xc7, 0x6c, 0xd6, 0x6f, 0xb1, 0x1f, 0x61, 0x3f, 0xce, 0x1e, 0x73,
-	0xdc, 0xd3, 0xb0, 0x98, 0xcb, 0xe2, 0xd1, 0xe3, 0xc2, 0xc2, 0x32, 0x4e, 0x62, 0x72, 0xd2, 0xff,
-	0xef, 0xcd, 0x7b, 0xff, 0xf7, 0xf8, 0xc3, 0x8f, 0x3e, 0x65, 0x59, 0x18, 0x38, 0x7c, 0x13, 0x26,
-	0x94, 0x93, 0xdc, 0xe1, 0x39, 0xcb, 0x7c, 0x4a, 0xec, 0x34, 0x63, 0x39, 0x33, 0x86, 0xaa, 0x6c,
-	0x1f, 0xca, 0x1f, 0xde, 0x52, 0x46, 0x59, 0x53, 0x73, 0xe4, 0x9f, 0x7a, 0x86, 0xbf, 0xc1, 0x57,
-	0x7f, 0x54, 0x9f, 0xe1, 0xc0, 0x97, 0x97, 0x7e, 0xb4, 0x26, 0x26, 0x18, 0x81, 0x71, 0xcf, 0x7d,
-	0x5f, 0x09, 0xa4, 0x40, 0x2d, 0xd0, 0xa0, 0xf0, 0xe3, 0x68, 0x8a, 0x1b, 0x89, 0x3d, 0x85, 0xa7,
-	0xfa, 0xc3, 0x0d, 0xd2, 0xf0, 0x57, 0xa8, 0xff, 0x22, 0x05, 0x37, 0x3e, 0x41, 0x7d, 0x45, 0x0a,
-	0x6e, 0x82, 0x51, 0x67, 0xdc, 0x73, 0xdf, 0x55, 0x02, 0x35, 0xba, 0x16, 0xa8, 0xaf, 0x9a, 0xa5,
-	0xc2, 0x5e, 0x03, 0xf7, 0xad, 0x4f, 0x00, 0x76, 0xbf, 0xd3, 0x8c, 0x70, 0x6e, 0xcc, 0xe0, 0xeb,
-	0x24, 0x0c, 0x56, 0x89, 0x1f, 0x1f, 0xfc, 0x51, 0x25, 0x50, 0xcb, 0x6a, 0x81, 0x86, 0x6a, 0xca,
-	0x81, 0x60, 0xaf, 0x2d, 0x1a, 0xff, 0xa1, 0x9e, 0x12, 0x92, 0x99, 0x2f, 0x46, 0x60, 0x3c, 0x70,
-	0x7f, 0x4a, 0x6b, 0xa9, 0x8f, 0xd6, 0x52, 0xe1, 0x47, 0x81, 0x26, 0x34, 0xcc, 0x2f, 0xd6, 0x0b,
-	0x3b, 0x60, 0xb1, 0x13, 0x30, 0x1e, 0x33, 0xbe, 0xff, 0x4c, 0xf8, 0x72, 0xe5, 0xe4, 0x45, 0x4a,
-	0xb8, 0x3d, 0x0f, 0x82, 0xf9, 0x72, 0x29, 0x97, 0xf2, 0x9a, 0x29, 0x86, 0x07, 0xfb, 0x29, 0xdb,
-	0x90, 0xec, 0xec, 0x3c, 0xf2, 0x29, 0x37, 0x3b, 0xcd, 0x7d, 0x9f, 0x4b, 0x81, 0xe0, 0x6f, 0x89,
-	0x7f, 0x48, 0x5a, 0x09, 0x04, 0xd3, 0x56, 0xd5, 0x02, 0xbd, 0xd9, 0x1b, 0xb7, 0x0c, 0x7b, 0x27,
-	0x0f, 0xd4, 0xfd, 0xee, 0xdf, 0xdb, 0xd2, 0x02, 0xdb, 0xd2, 0x02, 0xf7, 0xa5, 0x05, 0xae, 0x77,
-	0x96, 0xb6, 0xdd, 0x59, 0xda, 0xdd, 0xce, 0xd2, 0xfe, 0xcd, 0x4e, 0x16, 0x9d, 0xab, 0x9c, 0xe5,
-	0xa2, 0x61, 0x30, 0x69, 0xe3, 0xbe, 0x3a, 0x26, 0x1f, 0x26, 0x39, 0xc9, 0x12, 0x3f, 0x52, 0x17,
-	0x2c, 0xba, 0x4d, 0xb4, 0x5f, 0x9e, 0x03, 0x00, 0x00, 0xff, 0xff, 0x8b, 0xbd, 0x41, 0x47, 0x22,
-	0x02, 0x00, 0x00,
+	// 414 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcf, 0xca, 0xd3, 0x40,
+	0x14, 0xc5, 0x13, 0x1b, 0x3f, 0xfd, 0xa6, 0x85, 0x62, 0x50, 0xac, 0x82, 0x99, 0x32, 0x1b, 0x0b,
+	0xd2, 0x04, 0x71, 0x65, 0xbb, 0xb1, 0x81, 0x8a, 0xe0, 0x46, 0x22, 0x6e, 0x44, 0x90, 0x34, 0x1d,
+	0xc7, 0x90, 0x3f, 0x13, 0x66, 0x52, 0x6b, 0xde, 0xc2, 0x47, 0xf0, 0x71, 0x5c, 0x76, 0xe9, 0x6a,
+	0x90, 0x74, 0x23, 0x59, 0x66, 0x29, 0x08, 0x32, 0x33, 0x69, 0xda, 0xad, 0xab, 0xf6, 0xfc, 0xee,
+	0xdc, 0x7b, 0xcf, 0x09, 0x17, 0x3c, 0x0a, 0x09, 0x65, 0x71, 0xe4, 0xf1, 0x7d, 0x9c, 0x13, 0x8e,
+	0x4b, 0x8f, 0x97, 0x94, 0x85, 0x04, 0xbb, 0x05, 0xa3, 0x25, 0xb5, 0xc7, 0xba, 0xec, 0x9e, 0xca,
+	0x0f, 0xef, 0x12, 0x4a, 0xa8, 0xaa, 0x79, 0xf2, 0x9f, 0x7e, 0x86, 0x5e, 0x80, 0x5b, 0x6f, 0x75,
+	0x9f, 0xed, 0x81, 0x9b, 0x5f, 0xc2, 0x74, 0x87, 0x27, 0xe6, 0xd4, 0x9c, 0x5d, 0xfb, 0x0f, 0x1a,
+	0x01, 0x35, 0x68, 0x05, 0x1c, 0x55, 0x61, 0x96, 0x2e, 0x90, 0x92, 0x28, 0xd0, 0x78, 0x61, 0xfd,
+	0xfe, 0x0e, 0x0d, 0xf4, 0x1c, 0x58, 0xaf, 0x71, 0xc5, 0xed, 0x27, 0xc0, 0x4a, 0x70, 0xc5, 0x27,
+	0xe6, 0x74, 0x30, 0xbb, 0xf6, 0xef, 0x37, 0x02, 0x2a, 0xdd, 0x0a, 0x38, 0xd4, 0xcd, 0x52, 0xa1,
+	0x40, 0xc1, 0xae, 0x35, 0x07, 0xa3, 0x6e, 0xf9, 0x3a, 0x2f, 0x59, 0x65, 0x3f, 0x06, 0x83, 0x04,
+	0x57, 0xdd, 0xfe, 0x7b, 0x8d, 0x80, 0x52, 0xb6, 0x02, 0x82, 0x7e, 0x00, 0x0a, 0x24, 0x3a, 0x5b,
+	0xbd, 0xf1, 0x5f, 0x56, 0xff, 0x9a, 0xe0, 0x6a, 0x4d, 0x18, 0xe6, 0xdc, 0x5e, 0x82, 0xdb, 0x79,
+	0x1c, 0x25, 0x79, 0x98, 0x9d, 0xf2, 0xc2, 0x46, 0xc0, 0x9e, 0xb5, 0x02, 0x8e, 0xf5, 0x9c, 0x13,
+	0x41, 0x41, 0x5f, 0xb4, 0x3f, 0x00, 0xab, 0xc0, 0x98, 0xa9, 0xed, 0x23, 0xff, 0x95, 0x8c, 0x2a,
+	0xf5, 0x39, 0xaa, 0x54, 0xe8, 0x8f, 0x80, 0x73, 0x12, 0x97, 0x9f, 0x77, 0x1b, 0x37, 0xa2, 0x99,
+	0x17, 0x51, 0x9e, 0x51, 0xde, 0xfd, 0xcc, 0xf9, 0x36, 0xf1, 0xca, 0xaa, 0xc0, 0xdc, 0x5d, 0x45,
+	0xd1, 0x6a, 0xbb, 0x95, 0xa6, 0x02, 0x35, 0xc5, 0x0e, 0xc0, 0xb0, 0xa0, 0x7b, 0xcc, 0x3e, 0x7e,
+	0x4a, 0x43, 0xc2, 0x27, 0x03, 0xf5, 0x3d, 0x9f, 0xd6, 0x02, 0x82, 0x37, 0x12, 0xbf, 0x94, 0xb4,
+	0x11, 0x10, 0x14, 0xbd, 0x6a, 0x05, 0xbc, 0xd3, 0x2d, 0xee, 0x19, 0x0a, 0x2e, 0x1e, 0xe8, 0xfc,
+	0xfe, 0xbb, 0x1f, 0xb5, 0x63, 0x1e, 0x6a, 0xc7, 0xfc, 0x55, 0x3b, 0xe6, 0xb7, 0xa3, 0x63, 0x1c,
+	0x8e, 0x8e, 0xf1, 0xf3, 0xe8, 0x18, 0xef, 0x97, 0x17, 0x46, 0x57, 0xfa, 0xae, 0xa4, 0xd1, 0x38,
+	0x9a, 0xf7, 0xe7, 0xf5, 0xf5, 0x7c, 0x69, 0x71, 0x5e, 0x62, 0x96, 0x87, 0xa9, 0x4e, 0xb0, 0xb9,
+	0x52, 0xa7, 0xf4, 0xec, 0xdf, 0x00, 0xc2, 0x7c, 0x1e, 0x81, 0x92, 0x02, 0x00, 0x00,
 }
 
 func (m *Storage) Marshal() (dAtA []byte, err error) {
@@ -271,6 +326,43 @@ func (m *Keys) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StorageEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintStorage(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Egress) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -356,6 +448,23 @@ func (m *Keys) Size() (n int) {
 	return n
 }
 
+func (m *StorageEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovStorage(uint64(l))
+	}
+	return n
+}
+
 func (m *Egress) Size() (n int) {
 	if m == nil {
 		return 0
@@ -555,6 +664,123 @@ func (m *Keys) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *StorageEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStorage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthStorage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Egress) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,754 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: agoric/swingset/watch.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type WatchStorageRequest struct {
+	Path []string `protobuf:"bytes,1,rep,name=path,proto3" json:"path" yaml:"path"`
+}
+
+func (m *WatchStorageRequest) Reset()         { *m = WatchStorageRequest{} }
+func (m *WatchStorageRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchStorageRequest) ProtoMessage()    {}
+func (*WatchStorageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a8097ee3ed169c, []int{0}
+}
+func (m *WatchStorageRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchStorageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchStorageRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchStorageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchStorageRequest.Merge(m, src)
+}
+func (m *WatchStorageRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchStorageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchStorageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchStorageRequest proto.InternalMessageInfo
+
+func (m *WatchStorageRequest) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+// StorageChange is the committed value of a storage path, or its deletion.
+type StorageChange struct {
+	Path        string `protobuf:"bytes,1,opt,name=path,proto3" json:"path" yaml:"path"`
+	Value       string `protobuf:"bytes,2,opt,name=value,proto3" json:"value" yaml:"value"`
+	BlockHeight int64  `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"blockHeight" yaml:"blockHeight"`
+	// deleted is set if the path has no value, as opposed to an empty one.
+	Deleted bool `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted" yaml:"deleted"`
+}
+
+func (m *StorageChange) Reset()         { *m = StorageChange{} }
+func (m *StorageChange) String() string { return proto.CompactTextString(m) }
+func (*StorageChange) ProtoMessage()    {}
+func (*StorageChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a8097ee3ed169c, []int{1}
+}
+func (m *StorageChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageChange.Merge(m, src)
+}
+func (m *StorageChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageChange proto.InternalMessageInfo
+
+func (m *StorageChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StorageChange) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *StorageChange) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *StorageChange) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*WatchStorageRequest)(nil), "agoric.swingset.WatchStorageRequest")
+	proto.RegisterType((*StorageChange)(nil), "agoric.swingset.StorageChange")
+}
+
+func init() { proto.RegisterFile("agoric/swingset/watch.proto", fileDescriptor_77a8097ee3ed169c) }
+
+var fileDescriptor_77a8097ee3ed169c = []byte{
+	// 372 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xbf, 0x4f, 0xdb, 0x40,
+	0x1c, 0xc5, 0x7d, 0x4d, 0xd2, 0xb4, 0x97, 0xb4, 0x95, 0xae, 0x95, 0xea, 0xa6, 0xaa, 0x2f, 0xb2,
+	0x5a, 0x29, 0x52, 0x85, 0x8d, 0x60, 0x40, 0x0a, 0x13, 0x66, 0xc9, 0x8a, 0x11, 0x42, 0xca, 0x82,
+	0x2e, 0xce, 0xe9, 0x6c, 0xe1, 0xf8, 0x82, 0x7d, 0x21, 0xe4, 0xbf, 0xe0, 0x4f, 0xe0, 0xcf, 0x61,
+	0xcc, 0xc8, 0x74, 0x42, 0xc9, 0x82, 0x3c, 0x7a, 0x60, 0x46, 0xb9, 0x4b, 0x20, 0xfc, 0x90, 0xd8,
+	0xfc, 0xfd, 0xbc, 0xf7, 0x3c, 0xbc, 0x7b, 0xf0, 0x37, 0x61, 0x3c, 0x8d, 0x02, 0x37, 0x1b, 0x47,
+	0x09, 0xcb, 0xa8, 0x70, 0xc7, 0x44, 0x04, 0xa1, 0x33, 0x4c, 0xb9, 0xe0, 0xe8, 0x9b, 0x16, 0x9d,
+	0x95, 0xd8, 0xf8, 0xc1, 0x38, 0xe3, 0x4a, 0x73, 0x17, 0x5f, 0xda, 0x66, 0x7b, 0xf0, 0xfb, 0xf1,
+	0x22, 0x75, 0x28, 0x78, 0x4a, 0x18, 0xf5, 0xe9, 0xd9, 0x88, 0x66, 0x02, 0xfd, 0x87, 0xe5, 0x21,
+	0x11, 0xa1, 0x09, 0x9a, 0xa5, 0xd6, 0x67, 0xef, 0x67, 0x2e, 0xb1, 0xba, 0x0b, 0x89, 0x6b, 0x13,
+	0x32, 0x88, 0xdb, 0xf6, 0xe2, 0xb2, 0x7d, 0x05, 0xed, 0x7b, 0x00, 0xbf, 0x2c, 0xf3, 0xfb, 0x21,
+	0x49, 0x18, 0x5d, 0x8b, 0x83, 0x77, 0xe3, 0xc8, 0x85, 0x95, 0x73, 0x12, 0x8f, 0xa8, 0xf9, 0x41,
+	0xb9, 0x7f, 0xe5, 0x12, 0x6b, 0x50, 0x48, 0x5c, 0xd7, 0x76, 0x75, 0xda, 0xbe, 0xc6, 0xa8, 0x03,
+	0xeb, 0xbd, 0x98, 0x07, 0xa7, 0x27, 0x21, 0x8d, 0x58, 0x28, 0xcc, 0x52, 0x13, 0xb4, 0x4a, 0xde,
+	0xbf, 0x5c, 0xe2, 0x9a, 0xe2, 0x1d, 0x85, 0x0b, 0x89, 0x91, 0x4e, 0xaf, 0x41, 0xdb, 0x5f, 0xb7,
+	0xa0, 0x1d, 0x58, 0xed, 0xd3, 0x98, 0x0a, 0xda, 0x37, 0xcb, 0x4d, 0xd0, 0xfa, 0xe4, 0xfd, 0xc9,
+	0x25, 0x5e, 0xa1, 0x42, 0xe2, 0xaf, 0xfa, 0x07, 0x4b, 0x60, 0xfb, 0x2b, 0xa9, 0x5d, 0xbe, 0xbb,
+	0xc2, 0xc6, 0x56, 0x17, 0x56, 0x54, 0x79, 0xe8, 0x00, 0x56, 0x97, 0x05, 0xa0, 0xbf, 0xce, 0x8b,
+	0xe2, 0x9d, 0x37, 0xfa, 0x6d, 0x58, 0xaf, 0x5c, 0xcf, 0x0a, 0xdc, 0x04, 0xde, 0xd1, 0xf5, 0xcc,
+	0x02, 0xd3, 0x99, 0x05, 0x6e, 0x67, 0x16, 0xb8, 0x9c, 0x5b, 0xc6, 0x74, 0x6e, 0x19, 0x37, 0x73,
+	0xcb, 0xe8, 0xee, 0xb2, 0x48, 0x84, 0xa3, 0x9e, 0x13, 0xf0, 0x81, 0xbb, 0xa7, 0x17, 0x10, 0xf0,
+	0x6c, 0x10, 0x05, 0x1b, 0x8f, 0x43, 0xb8, 0x78, 0xda, 0x44, 0x94, 0x08, 0x9a, 0x26, 0x24, 0x76,
+	0xc5, 0x64, 0x48, 0xb3, 0xde, 0x47, 0xf5, 0xec, 0xdb, 0x0f, 0x03, 0x00, 0xb0, 0x87, 0x83, 0x8e,
+	0x3c, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WatchClient is the client API for Watch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WatchClient interface {
+	// Storage streams the changes at or below a storage path, after each block
+	// that makes them is committed.
+	Storage(ctx context.Context, in *WatchStorageRequest, opts ...grpc.CallOption) (Watch_StorageClient, error)
+}
+
+type watchClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewWatchClient(cc grpc1.ClientConn) WatchClient {
+	return &watchClient{cc}
+}
+
+func (c *watchClient) Storage(ctx context.Context, in *WatchStorageRequest, opts ...grpc.CallOption) (Watch_StorageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Watch_serviceDesc.Streams[0], "/agoric.swingset.Watch/Storage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchStorageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Watch_StorageClient interface {
+	Recv() (*StorageChange, error)
+	grpc.ClientStream
+}
+
+type watchStorageClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchStorageClient) Recv() (*StorageChange, error) {
+	m := new(StorageChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WatchServer is the server API for Watch service.
+type WatchServer interface {
+	// Storage streams the changes at or below a storage path, after each block
+	// that makes them is committed.
+	Storage(*WatchStorageRequest, Watch_StorageServer) error
+}
+
+// UnimplementedWatchServer can be embedded to have forward compatible implementations.
+type UnimplementedWatchServer struct {
+}
+
+func (*UnimplementedWatchServer) Storage(req *WatchStorageRequest, srv Watch_StorageServer) error {
+	return status.Errorf(codes.Unimplemented, "method Storage not implemented")
+}
+
+func RegisterWatchServer(s grpc1.Server, srv WatchServer) {
+	s.RegisterService(&_Watch_serviceDesc, srv)
+}
+
+func _Watch_Storage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStorageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WatchServer).Storage(m, &watchStorageServer{stream})
+}
+
+type Watch_StorageServer interface {
+	Send(*StorageChange) error
+	grpc.ServerStream
+}
+
+type watchStorageServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchStorageServer) Send(m *StorageChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Watch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "agoric.swingset.Watch",
+	HandlerType: (*WatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Storage",
+			Handler:       _Watch_Storage_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "agoric/swingset/watch.proto",
+}
+
+func (m *WatchStorageRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchStorageRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchStorageRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for iNdEx := len(m.Path) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Path[iNdEx])
+			copy(dAtA[i:], m.Path[iNdEx])
+			i = encodeVarintWatch(dAtA, i, uint64(len(m.Path[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Deleted {
+		i--
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintWatch(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintWatch(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintWatch(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWatch(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWatch(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WatchStorageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		for _, s := range m.Path {
+			l = len(s)
+			n += 1 + l + sovWatch(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StorageChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovWatch(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovWatch(uint64(l))
+	}
+	if m.BlockHeight != 0 {
+		n += 1 + sovWatch(uint64(m.BlockHeight))
+	}
+	if m.Deleted {
+		n += 2
+	}
+	return n
+}
+
+func sovWatch(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWatch(x uint64) (n int) {
+	return sovWatch(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WatchStorageRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchStorageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchStorageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = append(m.Path, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthWatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWatch(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWatch
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWatch
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWatch
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWatch
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWatch
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWatch        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWatch          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWatch = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,179 @@
+package swingset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KernelBundleFileName is the file beside genesis.json to which an export
+// writes the kernel state.
+const KernelBundleFileName = "swingset-kernel.bundle"
+
+type exportKernelAction struct {
+	Type        string `json:"type"`
+	StoragePort int    `json:"storagePort"`
+	BlockHeight int64  `json:"blockHeight"`
+	BlockTime   int64  `json:"blockTime"`
+}
+
+type importKernelAction struct {
+	Type         string `json:"type"`
+	StoragePort  int    `json:"storagePort"`
+	BlockHeight  int64  `json:"blockHeight"`
+	BlockTime    int64  `json:"blockTime"`
+	ChainID      string `json:"chainID"`
+	ExportHeight int64  `json:"exportHeight"`
+	Hash         string `json:"hash"`
+	Bundle       []byte `json:"bundle"`
+}
+
+// exportKernelReply is the controller's reply to EXPORT_KERNEL.  A controller
+// that keeps its bundles elsewhere may return only their hash.
+type exportKernelReply struct {
+	Hash   string `json:"hash"`
+	Bundle []byte `json:"bundle"`
+}
+
+func (action *exportKernelAction) toProto() *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+		Body: &types.ControllerAction_ExportKernel{ExportKernel: &types.ExportKernelAction{
+			StoragePort: int32(action.StoragePort),
+		}},
+	}
+}
+
+func (action *importKernelAction) toProto() *types.ControllerAction {
+	return &types.ControllerAction{
+		Type:        action.Type,
+		BlockHeight: action.BlockHeight,
+		BlockTime:   action.BlockTime,
+		Body: &types.ControllerAction_ImportKernel{ImportKernel: &types.ImportKernelAction{
+			StoragePort:  int32(action.StoragePort),
+			ChainID:      action.ChainID,
+			ExportHeight: action.ExportHeight,
+			Hash:         action.Hash,
+			Bundle:       action.Bundle,
+		}},
+	}
+}
+
+// bundleHash returns the hex SHA-256 of a kernel bundle.
+func bundleHash(bundle []byte) string {
+	sum := sha256.Sum256(bundle)
+	return hex.EncodeToString(sum[:])
+}
+
+// isBundleHash returns whether hash is a hex SHA-256.
+func isBundleHash(hash string) bool {
+	bz, err := hex.DecodeString(hash)
+	return err == nil && len(bz) == sha256.Size
+}
+
+// ExportKernel asks the controller for the kernel state as of the block
+// being exported.  If exportDir is set, the bundle is written there rather
+// than returned inline.  It returns nil if the controller cannot export.
+func ExportKernel(ctx sdk.Context, keeper Keeper, controller *Controller, exportDir string) (*types.KernelExport, error) {
+	if !controller.Supports(CapabilityKernelExport) {
+		fmt.Fprintln(os.Stderr, "The SwingSet controller cannot export its kernel; exporting without kernel state")
+		return nil, nil
+	}
+
+	action := &exportKernelAction{
+		Type:        "EXPORT_KERNEL",
+		StoragePort: controller.GetPort("storage"),
+		BlockHeight: ctx.BlockHeight(),
+		BlockTime:   ctx.BlockTime().Unix(),
+	}
+	b, err := controller.EncodeAction(action)
+	if err != nil {
+		return nil, err
+	}
+	out, err := keeper.CallToController(ctx, action.Type, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var reply exportKernelReply
+	if err := json.Unmarshal([]byte(out), &reply); err != nil {
+		return nil, fmt.Errorf("cannot parse EXPORT_KERNEL reply: %w", err)
+	}
+	if reply.Bundle != nil {
+		hash := bundleHash(reply.Bundle)
+		if reply.Hash != "" && reply.Hash != hash {
+			return nil, fmt.Errorf("kernel bundle has hash %s, but the controller said %s", hash, reply.Hash)
+		}
+		reply.Hash = hash
+	} else if !isBundleHash(reply.Hash) {
+		return nil, fmt.Errorf("EXPORT_KERNEL returned neither a bundle nor a hash")
+	}
+
+	export := &types.KernelExport{
+		BlockHeight: ctx.BlockHeight(),
+		Hash:        reply.Hash,
+		Bundle:      reply.Bundle,
+	}
+	if exportDir != "" && export.Bundle != nil {
+		if err := os.MkdirAll(exportDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(filepath.Join(exportDir, KernelBundleFileName), export.Bundle, 0644); err != nil {
+			return nil, err
+		}
+		export.File = KernelBundleFileName
+		export.Bundle = nil
+	}
+	fmt.Fprintf(os.Stderr, "Exported SwingSet kernel at height %d, hash %s\n", export.BlockHeight, export.Hash)
+	return export, nil
+}
+
+// ImportKernel hands an exported kernel state back to the controller, reading
+// its bundle from dir if it was written to a file.
+func ImportKernel(ctx sdk.Context, keeper Keeper, controller *Controller, export *types.KernelExport, dir string) error {
+	if !controller.Supports(CapabilityKernelExport) {
+		return fmt.Errorf("the SwingSet controller cannot import a kernel")
+	}
+
+	bundle := export.Bundle
+	if export.File != "" {
+		var err error
+		if bundle, err = ioutil.ReadFile(filepath.Join(dir, export.File)); err != nil {
+			return err
+		}
+	}
+	if bundle != nil {
+		if hash := bundleHash(bundle); hash != export.Hash {
+			return fmt.Errorf("kernel bundle has hash %s, expected %s", hash, export.Hash)
+		}
+	}
+
+	action := &importKernelAction{
+		Type:         "IMPORT_KERNEL",
+		StoragePort:  controller.GetPort("storage"),
+		BlockHeight:  ctx.BlockHeight(),
+		BlockTime:    ctx.BlockTime().Unix(),
+		ChainID:      ctx.ChainID(),
+		ExportHeight: export.BlockHeight,
+		Hash:         export.Hash,
+		Bundle:       bundle,
+	}
+	b, err := controller.EncodeAction(action)
+	if err != nil {
+		return err
+	}
+	if _, err := keeper.CallToController(ctx, action.Type, b); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported SwingSet kernel from height %d, hash %s\n", export.BlockHeight, export.Hash)
+	return nil
+}
@@ -0,0 +1,242 @@
+package swingset
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+var testBundle = []byte(`{"kernel":"state"}`)
+
+// kernelController is a controller that answers EXPORT_KERNEL with reply, and
+// records the kernel actions it is sent.
+type kernelController struct {
+	*Controller
+	keeper  Keeper
+	ctx     sdk.Context
+	reply   string
+	actions []map[string]interface{}
+}
+
+func newKernelController(t *testing.T, capabilities ...string) *kernelController {
+	cctx := newTestControllerContext(t, true)
+	kc := &kernelController{
+		Controller: NewController(),
+		keeper:     *cctx.Keeper,
+		ctx:        cctx.Context.WithBlockHeight(7).WithChainID("kernel-test"),
+	}
+	kc.RegisterPortHandler("storage", NewStorageHandler())
+	kc.SetHandshake(&Handshake{ProtocolVersion: ControllerProtocolVersion, Capabilities: capabilities})
+	kc.keeper.CallToController = func(ctx sdk.Context, actionType, str string) (string, error) {
+		var action map[string]interface{}
+		if err := json.Unmarshal([]byte(str), &action); err != nil {
+			t.Fatalf("%s is not JSON: %v", actionType, err)
+		}
+		if action["type"] != actionType {
+			t.Errorf("%s action has type %v", actionType, action["type"])
+		}
+		kc.actions = append(kc.actions, action)
+		if actionType == "EXPORT_KERNEL" {
+			return kc.reply, nil
+		}
+		return "true", nil
+	}
+	return kc
+}
+
+func (kc *kernelController) export(dir string) (*types.KernelExport, error) {
+	return ExportKernel(kc.ctx, kc.keeper, kc.Controller, dir)
+}
+
+func (kc *kernelController) importKernel(export *types.KernelExport, dir string) error {
+	return ImportKernel(kc.ctx, kc.keeper, kc.Controller, export, dir)
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bz)
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "swingset-kernel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// checkImport checks that the controller was last sent the export's kernel.
+func (kc *kernelController) checkImport(t *testing.T, export *types.KernelExport, bundle []byte) {
+	t.Helper()
+	if len(kc.actions) == 0 {
+		t.Fatal("no IMPORT_KERNEL")
+	}
+	var action importKernelAction
+	if err := json.Unmarshal([]byte(mustMarshal(t, kc.actions[len(kc.actions)-1])), &action); err != nil {
+		t.Fatal(err)
+	}
+	want := importKernelAction{
+		Type:         "IMPORT_KERNEL",
+		StoragePort:  kc.GetPort("storage"),
+		BlockHeight:  kc.ctx.BlockHeight(),
+		BlockTime:    kc.ctx.BlockTime().Unix(),
+		ChainID:      "kernel-test",
+		ExportHeight: export.BlockHeight,
+		Hash:         export.Hash,
+		Bundle:       bundle,
+	}
+	if mustMarshal(t, action) != mustMarshal(t, want) {
+		t.Errorf("imported with %+v; want %+v", action, want)
+	}
+}
+
+func TestKernelExportRoundTrip(t *testing.T) {
+	hash := bundleHash(testBundle)
+	for _, tc := range []struct {
+		name  string
+		reply exportKernelReply
+		toDir bool
+		want  types.KernelExport
+	}{
+		{"inline", exportKernelReply{Bundle: testBundle}, false,
+			types.KernelExport{BlockHeight: 7, Hash: hash, Bundle: testBundle}},
+		{"with hash", exportKernelReply{Hash: hash, Bundle: testBundle}, false,
+			types.KernelExport{BlockHeight: 7, Hash: hash, Bundle: testBundle}},
+		{"to file", exportKernelReply{Bundle: testBundle}, true,
+			types.KernelExport{BlockHeight: 7, Hash: hash, File: KernelBundleFileName}},
+		{"hash only", exportKernelReply{Hash: hash}, true,
+			types.KernelExport{BlockHeight: 7, Hash: hash}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kc := newKernelController(t, CapabilityKernelExport)
+			kc.reply = mustMarshal(t, tc.reply)
+			var dir string
+			if tc.toDir {
+				dir = tempDir(t)
+			}
+
+			export, err := kc.export(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if export == nil || mustMarshal(t, export) != mustMarshal(t, tc.want) {
+				t.Fatalf("exported %+v; want %+v", export, tc.want)
+			}
+			if err := validateKernelExport(export); err != nil {
+				t.Errorf("export does not validate: %v", err)
+			}
+			if len(kc.actions) != 1 || kc.actions[0]["storagePort"] != float64(kc.GetPort("storage")) ||
+				kc.actions[0]["blockHeight"] != float64(7) {
+				t.Errorf("exported with %v", kc.actions)
+			}
+			if export.File != "" {
+				bundle, err := ioutil.ReadFile(filepath.Join(dir, export.File))
+				if err != nil || string(bundle) != string(testBundle) {
+					t.Errorf("bundle file has %q, %v", bundle, err)
+				}
+			}
+
+			if err := kc.importKernel(export, dir); err != nil {
+				t.Fatal(err)
+			}
+			var bundle []byte
+			if tc.reply.Bundle != nil {
+				bundle = testBundle
+			}
+			kc.checkImport(t, export, bundle)
+		})
+	}
+}
+
+func TestExportKernelBadReply(t *testing.T) {
+	for _, tc := range []struct {
+		reply, want string
+	}{
+		{`not JSON`, "cannot parse EXPORT_KERNEL reply"},
+		{`{}`, "neither a bundle nor a hash"},
+		{`{"hash":"abcd"}`, "neither a bundle nor a hash"},
+		{mustMarshal(t, exportKernelReply{Hash: bundleHash([]byte("other")), Bundle: testBundle}),
+			"but the controller said " + bundleHash([]byte("other"))},
+	} {
+		kc := newKernelController(t, CapabilityKernelExport)
+		kc.reply = tc.reply
+		dir := tempDir(t)
+		export, err := kc.export(dir)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("reply %s exported %+v, %v; want %q", tc.reply, export, err, tc.want)
+		}
+		if _, err := os.Stat(filepath.Join(dir, KernelBundleFileName)); !os.IsNotExist(err) {
+			t.Errorf("reply %s wrote a bundle file", tc.reply)
+		}
+	}
+}
+
+func TestImportKernelBundleHash(t *testing.T) {
+	hash := bundleHash(testBundle)
+	dir := tempDir(t)
+	if err := ioutil.WriteFile(filepath.Join(dir, KernelBundleFileName), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		export types.KernelExport
+		want   string
+	}{
+		{"inline", types.KernelExport{Hash: hash, Bundle: []byte("tampered")}, "expected " + hash},
+		{"file", types.KernelExport{Hash: hash, File: KernelBundleFileName}, "expected " + hash},
+		{"missing file", types.KernelExport{Hash: hash, File: "missing.bundle"}, "missing.bundle"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kc := newKernelController(t, CapabilityKernelExport)
+			err := kc.importKernel(&tc.export, dir)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("imported with %v; want %q", err, tc.want)
+			}
+			if len(kc.actions) != 0 {
+				t.Errorf("sent %v", kc.actions)
+			}
+		})
+	}
+}
+
+func TestKernelExportCapability(t *testing.T) {
+	export := &types.KernelExport{Hash: bundleHash(testBundle), Bundle: testBundle}
+	for _, tc := range []struct {
+		name string
+		hs   *Handshake
+	}{
+		{"no handshake", nil},
+		{"legacy", &Handshake{}},
+		{"other capabilities", &Handshake{ProtocolVersion: 1, Capabilities: []string{CapabilityNestedUpcalls}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kc := newKernelController(t)
+			kc.SetHandshake(tc.hs)
+			kc.reply = mustMarshal(t, exportKernelReply{Bundle: testBundle})
+
+			// Without the capability, the export goes ahead without a kernel...
+			if got, err := kc.export(""); got != nil || err != nil {
+				t.Errorf("exported %+v, %v", got, err)
+			}
+			// ...but a kernel cannot be imported.
+			if err := kc.importKernel(export, ""); err == nil {
+				t.Error("imported a kernel")
+			}
+			if len(kc.actions) != 0 {
+				t.Errorf("sent %v", kc.actions)
+			}
+		})
+	}
+}
@@ -82,16 +82,22 @@ func (AppModuleBasic) GetTxCmd() *cobra.Command {
 
 type AppModule struct {
 	AppModuleBasic
-	keeper Keeper
+	keeper       Keeper
+	controller   *Controller
+	storageFiles *StorageFilesConfig
 }
 
-// NewAppModule creates a new AppModule Object
-func NewAppModule(k Keeper) AppModule {
+// NewAppModule creates a new AppModule Object, registering its "dibc" port
+// with the app's controller.  Genesis storage files are found and written
+// according to storageFiles.
+func NewAppModule(k Keeper, controller *Controller, storageFiles *StorageFilesConfig) AppModule {
 	am := AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         k,
+		controller:     controller,
+		storageFiles:   storageFiles,
 	}
-	RegisterPortHandler("dibc", NewIBCChannelHandler(am))
+	controller.RegisterPortHandler("dibc", NewIBCChannelHandler(am))
 	return am
 }
 
@@ -102,7 +108,7 @@ func (AppModule) Name() string {
 func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
 
 func (am AppModule) Route() sdk.Route {
-	return sdk.NewRoute(types.RouterKey, NewHandler(am.keeper))
+	return sdk.NewRoute(types.RouterKey, NewHandler(am.keeper, am.controller))
 }
 
 func (am AppModule) QuerierRoute() string {
@@ -122,14 +128,14 @@ func (am AppModule) RegisterQueryService(server grpc.Server) {
 }
 
 func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
-	err := BeginBlock(ctx, req, am.keeper)
+	err := BeginBlock(ctx, req, am.keeper, am.controller)
 	if err != nil {
 		fmt.Println("BeginBlock error:", err)
 	}
 }
 
 func (am AppModule) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.ValidatorUpdate {
-	valUpdate, err := EndBlock(ctx, req, am.keeper)
+	valUpdate, err := EndBlock(ctx, req, am.keeper, am.controller)
 	if err != nil {
 		fmt.Println("EndBlock error:", err)
 	}
@@ -144,10 +150,14 @@ func (am AppModule) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.V
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONMarshaler, data json.RawMessage) []abci.ValidatorUpdate {
 	var genesisState types.GenesisState
 	cdc.MustUnmarshalJSON(data, &genesisState)
-	return InitGenesis(ctx, am.keeper, &genesisState)
+	var storageDir string
+	if am.storageFiles != nil {
+		storageDir = am.storageFiles.Dir
+	}
+	return InitGenesis(ctx, am.keeper, am.controller, &genesisState, storageDir)
 }
 
 func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONMarshaler) json.RawMessage {
-	gs := ExportGenesis(ctx, am.keeper)
+	gs := ExportGenesis(ctx, am.keeper, am.controller, am.storageFiles)
 	return cdc.MustMarshalJSON(gs)
 }
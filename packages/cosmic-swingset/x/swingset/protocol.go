@@ -0,0 +1,129 @@
+package swingset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ControllerProtocolVersion is the controller protocol this node speaks.
+	ControllerProtocolVersion = 1
+	// MinControllerProtocolVersion is the oldest controller protocol this node
+	// still accepts.  Version 0 is a kernel that answers AG_COSMOS_INIT with a
+	// bare `true`.
+	MinControllerProtocolVersion = 0
+)
+
+// Capabilities that either side of the controller protocol may advertise.
+const (
+	// CapabilityNestedUpcalls means downcalls run against the innermost of
+	// several nested upcalls.
+	CapabilityNestedUpcalls = "nested-upcalls"
+	// CapabilityEmptyStorageValues means a storage "set" of "" stores an empty
+	// value, rather than deleting, which is done by "delete".
+	CapabilityEmptyStorageValues = "empty-storage-values"
+	// CapabilityKernelExport means the controller answers EXPORT_KERNEL with
+	// its kernel state, and restores it from IMPORT_KERNEL.
+	CapabilityKernelExport = "kernel-export"
+)
+
+// ControllerCapabilities are the capabilities this node advertises.
+var ControllerCapabilities = []string{
+	CapabilityNestedUpcalls,
+	CapabilityEmptyStorageValues,
+	CapabilityKernelExport,
+}
+
+// Handshake is one side's half of the AG_COSMOS_INIT negotiation.
+type Handshake struct {
+	ProtocolVersion    int      `json:"protocolVersion"`
+	MinProtocolVersion int      `json:"minProtocolVersion"`
+	Capabilities       []string `json:"capabilities"`
+	// Encodings are offered by the node, and the controller picks Encoding.
+	Encodings []string `json:"encodings,omitempty"`
+	Encoding  string   `json:"encoding,omitempty"`
+}
+
+// NodeHandshake returns this node's half of the negotiation.
+func NodeHandshake() Handshake {
+	return Handshake{
+		ProtocolVersion:    ControllerProtocolVersion,
+		MinProtocolVersion: MinControllerProtocolVersion,
+		Capabilities:       ControllerCapabilities,
+		Encodings:          ControllerEncodings,
+	}
+}
+
+// ParseHandshake decodes the controller's reply to AG_COSMOS_INIT.  A legacy
+// `true` reply is a version 0 controller without capabilities.
+func ParseHandshake(reply string) (*Handshake, error) {
+	if strings.TrimSpace(reply) == "true" {
+		return &Handshake{}, nil
+	}
+	hs := new(Handshake)
+	if err := json.Unmarshal([]byte(reply), hs); err != nil {
+		return nil, fmt.Errorf("cannot parse controller handshake %q: %w", reply, err)
+	}
+	return hs, nil
+}
+
+// Has returns whether capability was advertised.
+func (hs *Handshake) Has(capability string) bool {
+	for _, c := range hs.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCompatible returns an error describing why the controller's handshake
+// cannot be used with this node, if so.
+func (hs *Handshake) CheckCompatible() error {
+	if hs.ProtocolVersion < MinControllerProtocolVersion || hs.ProtocolVersion > ControllerProtocolVersion {
+		return fmt.Errorf(
+			"controller speaks protocol version %d, but this node supports versions %d through %d",
+			hs.ProtocolVersion, MinControllerProtocolVersion, ControllerProtocolVersion,
+		)
+	}
+	if hs.MinProtocolVersion > ControllerProtocolVersion {
+		return fmt.Errorf(
+			"controller requires protocol version %d or newer, but this node supports at most version %d",
+			hs.MinProtocolVersion, ControllerProtocolVersion,
+		)
+	}
+	switch hs.Encoding {
+	case "", EncodingJSON, EncodingProtobuf:
+	default:
+		return fmt.Errorf("controller chose unknown encoding %q", hs.Encoding)
+	}
+	return nil
+}
+
+// SetHandshake records the controller's accepted handshake.
+func (c *Controller) SetHandshake(hs *Handshake) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handshake = hs
+}
+
+// Handshake returns the controller's accepted handshake, or nil if the
+// controller must (again) be initialized.
+func (c *Controller) Handshake() *Handshake {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.handshake
+}
+
+// RequireHandshake forgets the controller's handshake, such as when a new
+// controller connects, so that it is initialized again before the next block.
+func (c *Controller) RequireHandshake() {
+	c.SetHandshake(nil)
+}
+
+// Supports returns whether the controller advertised capability.
+func (c *Controller) Supports(capability string) bool {
+	hs := c.Handshake()
+	return hs != nil && hs.Has(capability)
+}
@@ -0,0 +1,76 @@
+package swingset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHandshake(t *testing.T) {
+	for _, tc := range []struct {
+		reply string
+		hs    *Handshake
+		err   bool
+	}{
+		// A version 0 controller.
+		{reply: "true", hs: &Handshake{}},
+		{reply: " true\n", hs: &Handshake{}},
+		{
+			reply: `{"protocolVersion":1,"minProtocolVersion":0,"capabilities":["nested-upcalls"],"encoding":"protobuf"}`,
+			hs:    &Handshake{ProtocolVersion: 1, Capabilities: []string{CapabilityNestedUpcalls}, Encoding: EncodingProtobuf},
+		},
+		{reply: "false", err: true},
+		{reply: "", err: true},
+		{reply: `{"protocolVersion":"1"}`, err: true},
+	} {
+		hs, err := ParseHandshake(tc.reply)
+		if tc.err {
+			if err == nil {
+				t.Errorf("parsed %q as %+v", tc.reply, hs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("cannot parse %q: %v", tc.reply, err)
+		} else if !reflect.DeepEqual(hs, tc.hs) {
+			t.Errorf("parsed %q as %+v; want %+v", tc.reply, hs, tc.hs)
+		}
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		hs   Handshake
+		ok   bool
+	}{
+		{"legacy", Handshake{}, true},
+		{"current", Handshake{ProtocolVersion: ControllerProtocolVersion, MinProtocolVersion: ControllerProtocolVersion}, true},
+		{"json", Handshake{ProtocolVersion: 1, Encoding: EncodingJSON}, true},
+		{"protobuf", Handshake{ProtocolVersion: 1, Encoding: EncodingProtobuf}, true},
+		{"too new", Handshake{ProtocolVersion: ControllerProtocolVersion + 1}, false},
+		{"negative", Handshake{ProtocolVersion: -1}, false},
+		{"requires newer", Handshake{ProtocolVersion: 1, MinProtocolVersion: ControllerProtocolVersion + 1}, false},
+		{"unknown encoding", Handshake{ProtocolVersion: 1, Encoding: "cbor"}, false},
+	} {
+		if err := tc.hs.CheckCompatible(); (err == nil) != tc.ok {
+			t.Errorf("%s: CheckCompatible gave %v", tc.name, err)
+		}
+	}
+}
+
+func TestControllerHandshake(t *testing.T) {
+	c := NewController()
+	if c.Encoding() != EncodingJSON || c.Supports(CapabilityNestedUpcalls) {
+		t.Error("a controller without a handshake has capabilities")
+	}
+
+	c.SetHandshake(&Handshake{ProtocolVersion: 1, Capabilities: []string{CapabilityKernelExport}, Encoding: EncodingProtobuf})
+	if c.Encoding() != EncodingProtobuf || !c.Supports(CapabilityKernelExport) || c.Supports(CapabilityNestedUpcalls) {
+		t.Errorf("handshake %+v was not applied", c.Handshake())
+	}
+
+	c.RequireHandshake()
+	if c.Handshake() != nil || c.Encoding() != EncodingJSON || c.Supports(CapabilityKernelExport) {
+		t.Error("RequireHandshake kept the old handshake")
+	}
+}
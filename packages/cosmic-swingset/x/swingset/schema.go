@@ -0,0 +1,405 @@
+package swingset
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Directions of messages in the schema registry.
+const (
+	SchemaUpcall   = "upcall"
+	SchemaDowncall = "downcall"
+)
+
+// MessageSchema describes one kind of message exchanged with the controller.
+type MessageSchema struct {
+	// Name is "TYPE" or "TYPE/event" for upcalls, and the port name for
+	// downcalls.
+	Name      string
+	Direction string
+	// Consts are the discriminating fields and their values.
+	Consts map[string]string
+	// Required are the fields a message must carry.  Every field is required
+	// if this is nil.
+	Required []string
+	Type     reflect.Type
+
+	// schema is the JSON Schema that messages are validated against, built
+	// once at registration.
+	schema map[string]interface{}
+}
+
+var schemaRegistry = make(map[string]*MessageSchema)
+
+// RegisterUpcallSchema records the Go definition of an upcall action.  event
+// is empty except for IBC_EVENT actions.
+func RegisterUpcallSchema(actionType, event string, sample interface{}) {
+	name := actionType
+	consts := map[string]string{"type": actionType}
+	if event != "" {
+		name += "/" + event
+		consts["event"] = event
+	}
+	registerSchema(&MessageSchema{
+		Name:      name,
+		Direction: SchemaUpcall,
+		Consts:    consts,
+		Type:      reflect.TypeOf(sample),
+	})
+}
+
+// RegisterDowncallSchema records the Go definition of the messages accepted
+// by a port.  Fields not listed in required may be omitted by the controller.
+func RegisterDowncallSchema(port string, sample interface{}, consts map[string]string, required ...string) {
+	registerSchema(&MessageSchema{
+		Name:      port,
+		Direction: SchemaDowncall,
+		Consts:    consts,
+		Required:  append([]string{}, required...),
+		Type:      reflect.TypeOf(sample),
+	})
+}
+
+func registerSchema(ms *MessageSchema) {
+	if _, ok := schemaRegistry[ms.Direction+":"+ms.Name]; ok {
+		panic(fmt.Sprintf("%s schema %s registered twice", ms.Direction, ms.Name))
+	}
+	ms.schema = ms.JSONSchema()
+	schemaRegistry[ms.Direction+":"+ms.Name] = ms
+}
+
+func init() {
+	RegisterUpcallSchema("BEGIN_BLOCK", "", beginBlockAction{})
+	RegisterUpcallSchema("END_BLOCK", "", endBlockAction{})
+	RegisterUpcallSchema("COMMIT_BLOCK", "", commitBlockAction{})
+	RegisterUpcallSchema("DELIVER_INBOUND", "", deliverInboundAction{})
+	RegisterUpcallSchema("PLEASE_PROVISION", "", provisionAction{})
+	RegisterUpcallSchema("EXPORT_KERNEL", "", exportKernelAction{})
+	RegisterUpcallSchema("IMPORT_KERNEL", "", importKernelAction{})
+	RegisterUpcallSchema("IBC_EVENT", "sendPacket", sendPacketAction{})
+	RegisterUpcallSchema("IBC_EVENT", "channelOpenInit", channelOpenInitEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "channelOpenTry", channelOpenTryEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "channelOpenAck", channelOpenAckEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "channelOpenConfirm", channelOpenConfirmEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "channelCloseInit", channelCloseInitEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "channelCloseConfirm", channelCloseConfirmEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "receivePacket", receivePacketEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "acknowledgementPacket", acknowledgementPacketEvent{})
+	RegisterUpcallSchema("IBC_EVENT", "timeoutPacket", timeoutPacketEvent{})
+
+	RegisterDowncallSchema("storage", storageMessage{}, nil, "method")
+	RegisterDowncallSchema("dibc", channelMessage{}, map[string]string{"type": "IBC_METHOD"}, "type", "method")
+}
+
+// MessageSchemas returns all registered schemas, sorted by direction and name.
+func MessageSchemas() []*MessageSchema {
+	schemas := make([]*MessageSchema, 0, len(schemaRegistry))
+	for _, ms := range schemaRegistry {
+		schemas = append(schemas, ms)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		if schemas[i].Direction != schemas[j].Direction {
+			return schemas[i].Direction > schemas[j].Direction
+		}
+		return schemas[i].Name < schemas[j].Name
+	})
+	return schemas
+}
+
+// JSONSchema returns the JSON Schema (draft 7) of the message, which the
+// caller may modify.
+func (ms *MessageSchema) JSONSchema() map[string]interface{} {
+	schema := typeSchema(ms.Type)
+	props, _ := schema["properties"].(map[string]interface{})
+	for field, value := range ms.Consts {
+		if prop, ok := props[field].(map[string]interface{}); ok {
+			prop["const"] = value
+		}
+	}
+	if ms.Required != nil {
+		relaxRequired(schema)
+		schema["required"] = ms.Required
+	}
+	schema["title"] = ms.Name
+	schema["description"] = fmt.Sprintf("SwingSet controller %s %s", ms.Direction, ms.Name)
+	return schema
+}
+
+// JSONSchemaDocument returns a single JSON Schema covering every message.
+func JSONSchemaDocument() map[string]interface{} {
+	upcalls := map[string]interface{}{}
+	downcalls := map[string]interface{}{}
+	for _, ms := range MessageSchemas() {
+		if ms.Direction == SchemaUpcall {
+			upcalls[ms.Name] = ms.JSONSchema()
+		} else {
+			downcalls[ms.Name] = ms.JSONSchema()
+		}
+	}
+	return map[string]interface{}{
+		"$schema":         "http://json-schema.org/draft-07/schema#",
+		"title":           "SwingSet controller protocol",
+		"protocolVersion": ControllerProtocolVersion,
+		"definitions": map[string]interface{}{
+			"upcalls":   upcalls,
+			"downcalls": downcalls,
+		},
+	}
+}
+
+// relaxRequired removes the required fields from schema and everything it
+// contains, for messages that the controller may abbreviate.
+func relaxRequired(schema map[string]interface{}) {
+	delete(schema, "required")
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, prop := range props {
+			if sub, ok := prop.(map[string]interface{}); ok {
+				relaxRequired(sub)
+			}
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties"} {
+		if sub, ok := schema[key].(map[string]interface{}); ok {
+			relaxRequired(sub)
+		}
+	}
+}
+
+// schemaDescriber is a type with a custom JSON encoding that describes its
+// own schema.
+type schemaDescriber interface {
+	jsonSchema() map[string]interface{}
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	schemaDescriberType = reflect.TypeOf((*schemaDescriber)(nil)).Elem()
+	accAddressType      = reflect.TypeOf(sdk.AccAddress{})
+)
+
+// typeSchema derives a JSON Schema from t as encoding/json would marshal it.
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == accAddressType:
+		return map[string]interface{}{"type": "string", "description": "bech32 address"}
+	case t.Implements(schemaDescriberType):
+		return reflect.Zero(t).Interface().(schemaDescriber).jsonSchema()
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// Custom encoding that we cannot describe.
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		// A nil pointer is marshaled as null.
+		schema := typeSchema(t.Elem())
+		if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []string{typ, "null"}
+		}
+		return schema
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": []string{"string", "null"}, "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		props := map[string]interface{}{}
+		required := []string{}
+		addStructFields(t, props, &required)
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+	// interface{} and anything else we can't pin down.
+	return map[string]interface{}{}
+}
+
+// addStructFields adds the fields of t, flattening embedded structs like
+// encoding/json does.
+func addStructFields(t reflect.Type, props map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructFields(ft, props, required)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			// Unexported.
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = typeSchema(ft)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// FindSchema returns the schema for an upcall action, or for a downcall to
+// the named port.
+func FindSchema(direction, name string) *MessageSchema {
+	return schemaRegistry[direction+":"+name]
+}
+
+// ValidateUpcall checks an outgoing action against its registered schema.
+func ValidateUpcall(action string) error {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(action), &obj); err != nil {
+		return err
+	}
+	name, _ := obj["type"].(string)
+	if event, ok := obj["event"].(string); ok && event != "" {
+		name += "/" + event
+	}
+	ms := FindSchema(SchemaUpcall, name)
+	if ms == nil {
+		return fmt.Errorf("no schema for upcall %q", name)
+	}
+	return validateValue(name, ms.schema, obj)
+}
+
+// ValidateDowncall checks an incoming message against its port's schema.
+func ValidateDowncall(port, msg string) error {
+	ms := FindSchema(SchemaDowncall, port)
+	if ms == nil {
+		return fmt.Errorf("no schema for port %q", port)
+	}
+	var obj interface{}
+	if err := json.Unmarshal([]byte(msg), &obj); err != nil {
+		return err
+	}
+	return validateValue(port, ms.schema, obj)
+}
+
+// validateValue checks v against the subset of JSON Schema that typeSchema
+// produces.
+func validateValue(path string, schema map[string]interface{}, v interface{}) error {
+	if types, ok := schemaTypes(schema["type"]); ok {
+		actual := jsonType(v)
+		found := false
+		for _, t := range types {
+			if t == actual || (t == "number" && actual == "integer") {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, " or "), actual)
+		}
+	}
+	if c, ok := schema["const"]; ok && c != v {
+		return fmt.Errorf("%s: expected %q, got %v", path, c, v)
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]string)
+		for _, name := range required {
+			if _, ok := val[name]; !ok {
+				return fmt.Errorf("%s: missing field %q", path, name)
+			}
+		}
+		for name, fv := range val {
+			sub, ok := props[name].(map[string]interface{})
+			if !ok {
+				if extra, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+					sub = extra
+				} else if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unexpected field %q", path, name)
+				} else {
+					continue
+				}
+			}
+			if err := validateValue(path+"."+name, sub, fv); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].([]interface{}); ok {
+			// A tuple.
+			if len(val) != len(items) {
+				return fmt.Errorf("%s: expected %d items, got %d", path, len(items), len(val))
+			}
+			for i, item := range val {
+				sub, _ := items[i].(map[string]interface{})
+				if err := validateValue(fmt.Sprintf("%s[%d]", path, i), sub, item); err != nil {
+					return err
+				}
+			}
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range val {
+				if err := validateValue(fmt.Sprintf("%s[%d]", path, i), items, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func schemaTypes(t interface{}) ([]string, bool) {
+	switch t := t.(type) {
+	case string:
+		return []string{t}, true
+	case []string:
+		return t, true
+	}
+	return nil, false
+}
+
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
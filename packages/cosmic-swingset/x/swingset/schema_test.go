@@ -0,0 +1,149 @@
+package swingset
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	schemas := MessageSchemas()
+	if len(schemas) != len(schemaRegistry) {
+		t.Fatalf("MessageSchemas returned %d of %d schemas", len(schemas), len(schemaRegistry))
+	}
+	for i, ms := range schemas {
+		if i > 0 {
+			prev := schemas[i-1]
+			if prev.Direction < ms.Direction || (prev.Direction == ms.Direction && prev.Name >= ms.Name) {
+				t.Errorf("%s %s sorts after %s %s", ms.Direction, ms.Name, prev.Direction, prev.Name)
+			}
+		}
+		if FindSchema(ms.Direction, ms.Name) != ms {
+			t.Errorf("cannot find %s %s", ms.Direction, ms.Name)
+		}
+		if !reflect.DeepEqual(ms.schema, ms.JSONSchema()) {
+			t.Errorf("%s %s changed after registration", ms.Direction, ms.Name)
+		}
+	}
+
+	// Every action that the module sends has a schema.
+	for _, action := range testActions() {
+		if err := ValidateUpcall(mustEncodeJSON(t, action)); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, name := range []string{"storage", "dibc"} {
+		if FindSchema(SchemaDowncall, name) == nil {
+			t.Errorf("no schema for port %s", name)
+		}
+	}
+	if FindSchema(SchemaDowncall, "BEGIN_BLOCK") != nil {
+		t.Error("found an upcall among the downcalls")
+	}
+}
+
+func TestRegisterSchemaTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registered BEGIN_BLOCK twice")
+		}
+	}()
+	RegisterUpcallSchema("BEGIN_BLOCK", "", beginBlockAction{})
+}
+
+func TestJSONSchemaIsACopy(t *testing.T) {
+	ms := FindSchema(SchemaUpcall, "BEGIN_BLOCK")
+	ms.JSONSchema()["properties"].(map[string]interface{})["extra"] = map[string]interface{}{}
+	if err := ValidateUpcall(`{"type":"BEGIN_BLOCK","storagePort":1,"blockHeight":1,"blockTime":1,"chainID":"c","extra":1}`); err == nil {
+		t.Error("changing a returned schema changed validation")
+	}
+}
+
+func mustEncodeJSON(t *testing.T, action interface{}) string {
+	encoded, err := EncodeAction(EncodingJSON, action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestValidateUpcall(t *testing.T) {
+	for _, tc := range []struct {
+		action string
+		err    string
+	}{
+		{`{"type":"COMMIT_BLOCK","blockHeight":1,"blockTime":2}`, ""},
+		{`{"type":"COMMIT_BLOCK","blockHeight":1}`, `missing field "blockTime"`},
+		{`{"type":"COMMIT_BLOCK","blockHeight":"1","blockTime":2}`, "expected integer, got string"},
+		{`{"type":"COMMIT_BLOCK","blockHeight":1,"blockTime":2,"extra":3}`, `unexpected field "extra"`},
+		{`{"type":"NO_SUCH_ACTION"}`, "no schema"},
+		{`{"type":"IBC_EVENT","event":"noSuchEvent"}`, "no schema"},
+		{`[]`, "cannot unmarshal"},
+		{
+			`{"type":"DELIVER_INBOUND","peer":"p","messages":[[1,"m"]],"ack":0,"storagePort":1,"blockHeight":1,"blockTime":2}`,
+			"",
+		},
+		{
+			`{"type":"DELIVER_INBOUND","peer":"p","messages":[["1","m"]],"ack":0,"storagePort":1,"blockHeight":1,"blockTime":2}`,
+			"messages[0][0]: expected integer",
+		},
+		{
+			`{"type":"DELIVER_INBOUND","peer":"p","messages":[[1]],"ack":0,"storagePort":1,"blockHeight":1,"blockTime":2}`,
+			"expected 2 items",
+		},
+	} {
+		err := ValidateUpcall(tc.action)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tc.action, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s gave %v; want %q", tc.action, err, tc.err)
+		}
+	}
+}
+
+func TestValidateDowncall(t *testing.T) {
+	for _, tc := range []struct {
+		port string
+		msg  string
+		err  string
+	}{
+		// Only the method is required of storage messages.
+		{"storage", `{"method":"get","key":"a"}`, ""},
+		{"storage", `{"method":"setMany","entries":[["a","1"]]}`, ""},
+		{"storage", `{"key":"a"}`, `missing field "method"`},
+		{"storage", `{"method":"get","key":1}`, "expected string"},
+		{"storage", `{"method":"get","bogus":1}`, `unexpected field "bogus"`},
+		{"dibc", `{"type":"IBC_METHOD","method":"sendPacket"}`, ""},
+		{"dibc", `{"type":"IBC_EVENT","method":"sendPacket"}`, `expected "IBC_METHOD"`},
+		{"nowhere", `{}`, "no schema"},
+	} {
+		err := ValidateDowncall(tc.port, tc.msg)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("%s %s: %v", tc.port, tc.msg, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s %s gave %v; want %q", tc.port, tc.msg, err, tc.err)
+		}
+	}
+}
+
+// BenchmarkValidateUpcall measures validating a DELIVER_INBOUND.
+func BenchmarkValidateUpcall(b *testing.B) {
+	mailbox := make([]inboundMessage, benchMessages)
+	for i := range mailbox {
+		mailbox[i] = inboundMessage{Num: uint64(i + 1), Body: "m"}
+	}
+	encoded, err := EncodeAction(EncodingJSON, &deliverInboundAction{Type: "DELIVER_INBOUND", Messages: mailbox})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ValidateUpcall(encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
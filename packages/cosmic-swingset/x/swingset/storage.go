@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
 
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
 type storageHandler struct{}
@@ -14,21 +19,206 @@ type storageMessage struct {
 	Method string `json:"method"`
 	Key    string `json:"key"`
 	Value  string `json:"value"`
+	// Entries are the [key, value] pairs of setMany.
+	Entries [][]string `json:"entries,omitempty"`
+	// Keys are the keys of getMany and deleteMany.
+	Keys []string `json:"keys,omitempty"`
+	// Expected is the current value required by setIfEqual, where null means
+	// absent.
+	Expected *string `json:"expected,omitempty"`
+	// After is the child key that keys, entries and values list after, so
+	// that the last key of one page starts the next.
+	After string `json:"after,omitempty"`
+	// Limit is the most children that keys, entries and values list.  Without
+	// one, listing more than maxStorageListing children is an error.
+	Limit uint64 `json:"limit,omitempty"`
 }
 
-func init() {
-	RegisterPortHandler("storage", NewStorageHandler())
+// maxStorageListing is the most children that keys, entries and values list
+// in one downcall.
+const maxStorageListing = 1000
+
+// conditionalResult is the reply to a conditional write: whether it was
+// applied, and the value now in storage, or null if absent.
+type conditionalResult struct {
+	OK    bool    `json:"ok"`
+	Value *string `json:"value"`
+}
+
+func conditionalReply(ok bool, value []byte, present bool) (string, error) {
+	res := conditionalResult{OK: ok}
+	if present {
+		s := string(value)
+		res.Value = &s
+	}
+	bytes, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
 }
 
 func NewStorageHandler() storageHandler {
 	return storageHandler{}
 }
 
-func (sh storageHandler) Receive(ctx *ControllerContext, str string) (ret string, err error) {
+// presentValue returns whether the controller's value for a key means it is
+// present, which "" does not unless the controller negotiated empty values.
+func presentValue(ctx *ControllerContext, value string) bool {
+	return value != "" || ctx.EmptyStorageValues
+}
+
+// setValue sets the value at path, or deletes it if the value is "" and the
+// controller has not negotiated empty values.
+func setValue(ctx *ControllerContext, sdkCtx sdk.Context, path, value string) error {
+	if !presentValue(ctx, value) {
+		ctx.Keeper.DeleteStorage(sdkCtx, path)
+		return nil
+	}
+	if err := ctx.Keeper.CheckStorageQuota(sdkCtx, path, []byte(value)); err != nil {
+		return err
+	}
+	ctx.Keeper.SetStorageBytes(sdkCtx, path, []byte(value))
+	return nil
+}
+
+// listChildren returns a page of the children of msg.Key, with their values
+// if withValues, as requested by msg.After and msg.Limit.
+func listChildren(ctx *ControllerContext, msg *storageMessage, withValues bool) ([]types.StorageEntry, error) {
+	limit := msg.Limit
+	if limit == 0 || limit > maxStorageListing {
+		limit = maxStorageListing
+	}
+	pageReq := &query.PageRequest{Limit: limit}
+	if msg.After != "" {
+		pageReq.Key = types.ChildKeyAfter(msg.After)
+	}
+
+	var entries []types.StorageEntry
+	var pageRes *query.PageResponse
+	var err error
+	if withValues {
+		entries, pageRes, err = ctx.Keeper.GetEntriesPage(ctx.Context, msg.Key, pageReq)
+	} else {
+		var keys []string
+		keys, pageRes, err = ctx.Keeper.GetKeysPage(ctx.Context, msg.Key, pageReq)
+		entries = make([]types.StorageEntry, len(keys))
+		for i, key := range keys {
+			entries[i].Key = key
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if msg.Limit == 0 && pageRes.NextKey != nil {
+		return nil, fmt.Errorf("storage %s has more than %d children; list them with a limit", msg.Key, maxStorageListing)
+	}
+	return entries, nil
+}
+
+func (sh storageHandler) Receive(ctx *ControllerContext, str string) (string, error) {
 	msg := new(storageMessage)
-	err = json.Unmarshal([]byte(str), &msg)
+	err := json.Unmarshal([]byte(str), &msg)
 	if err != nil {
-		return
+		return "", err
+	}
+	return sh.receive(ctx, msg)
+}
+
+// ReceiveProto handles a binary-encoded types.StorageMessage.
+func (sh storageHandler) ReceiveProto(ctx *ControllerContext, bz []byte) (string, error) {
+	var pm types.StorageMessage
+	if err := pm.Unmarshal(bz); err != nil {
+		return "", err
+	}
+	return sh.receive(ctx, storageMessageFromProto(&pm))
+}
+
+// cacheContext returns a branch of ctx, and a function that writes the branch
+// and its events back to ctx.
+func cacheContext(ctx sdk.Context) (sdk.Context, func()) {
+	cacheCtx, write := ctx.CacheContext()
+	return cacheCtx, func() {
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
+
+// size returns the number of bytes in msg that are charged for.
+func (msg *storageMessage) size() int {
+	n := len(msg.Key) + len(msg.Value)
+	if msg.Expected != nil {
+		n += len(*msg.Expected)
+	}
+	for _, entry := range msg.Entries {
+		for _, s := range entry {
+			n += len(s)
+		}
+	}
+	for _, key := range msg.Keys {
+		n += len(key)
+	}
+	return n
+}
+
+// validate checks each path in msg, so that the keeper is never handed one
+// that could name an entry in two ways.  The listings may name the root.
+func (msg *storageMessage) validate() error {
+	switch msg.Method {
+	case "keys", "entries", "values", "size":
+		if msg.Key == "" {
+			return nil
+		}
+	case "setMany":
+		for _, entry := range msg.Entries {
+			if len(entry) != 2 {
+				return fmt.Errorf("setMany entry %q is not a [key, value] pair", entry)
+			}
+			if err := types.ValidatePath(entry[0]); err != nil {
+				return fmt.Errorf("invalid key %q: %w", entry[0], err)
+			}
+		}
+		return nil
+	case "getMany", "deleteMany":
+		for _, key := range msg.Keys {
+			if err := types.ValidatePath(key); err != nil {
+				return fmt.Errorf("invalid key %q: %w", key, err)
+			}
+		}
+		return nil
+	case "moveSubtree":
+		if err := types.ValidatePath(msg.Value); err != nil {
+			return fmt.Errorf("invalid destination %q: %w", msg.Value, err)
+		}
+	case "set", "delete", "get", "has", "setIfAbsent", "setIfEqual", "increment", "deleteSubtree":
+	default:
+		// apply rejects the method.
+		return nil
+	}
+	if err := types.ValidatePath(msg.Key); err != nil {
+		return fmt.Errorf("invalid key %q: %w", msg.Key, err)
+	}
+	return nil
+}
+
+// storageMethods are the methods of the storage port.
+var storageMethods = map[string]bool{
+	"set": true, "delete": true, "get": true, "has": true,
+	"setMany": true, "getMany": true, "deleteMany": true,
+	"setIfAbsent": true, "setIfEqual": true, "increment": true,
+	"deleteSubtree": true, "moveSubtree": true,
+	"keys": true, "entries": true, "values": true, "size": true,
+}
+
+func (sh storageHandler) receive(ctx *ControllerContext, msg *storageMessage) (ret string, err error) {
+	// Don't let the controller choose metric labels.
+	if !storageMethods[msg.Method] {
+		countStorageMethod("unknown")
+		return "", errors.New("Unrecognized msg.Method " + msg.Method)
+	}
+	countStorageMethod(msg.Method)
+	if err := msg.validate(); err != nil {
+		return "", err
 	}
 
 	// Allow recovery from OutOfGas panics so that we don't crash
@@ -36,9 +226,9 @@ func (sh storageHandler) Receive(ctx *ControllerContext, str string) (ret string
 		if r := recover(); r != nil {
 			switch rType := r.(type) {
 			case sdk.ErrorOutOfGas:
-				err = fmt.Errorf(
-					"out of gas in location: %v; gasUsed: %d",
-					rType.Descriptor, ctx.Context.GasMeter().GasConsumed(),
+				err = sdkerrors.Wrapf(
+					sdkerrors.ErrOutOfGas, "out of gas in location: %v; gasUsed: %d",
+					rType.Descriptor, ctx.GasMeter.GasConsumed(),
 				)
 			default:
 				// Not ErrorOutOfGas, so panic again.
@@ -47,53 +237,175 @@ func (sh storageHandler) Receive(ctx *ControllerContext, str string) (ret string
 		}
 	}()
 
+	gas := newStorageGas(ctx)
+	gas.bytes(msg.size())
+	ret, err = sh.apply(ctx, gas, msg)
+	if err == nil {
+		gas.bytes(len(ret))
+	}
+	return ret, err
+}
+
+// apply performs a storage downcall, charging gas for each key it reads or
+// writes.
+func (sh storageHandler) apply(ctx *ControllerContext, gas storageGas, msg *storageMessage) (string, error) {
 	// Handle generic paths.
 	switch msg.Method {
 	case "set":
-		storage := NewStorage()
-		storage.Value = msg.Value
-		//fmt.Printf("giving Keeper.SetStorage(%s) %s\n", msg.Key, storage.Value)
-		ctx.Keeper.SetStorage(ctx.Context, msg.Key, storage)
+		gas.writes(1)
+		//fmt.Printf("giving Keeper.SetStorage(%s) %s\n", msg.Key, msg.Value)
+		if err := setValue(ctx, ctx.Context, msg.Key, msg.Value); err != nil {
+			return "", err
+		}
+		return "true", nil
+
+	case "delete":
+		gas.writes(1)
+		ctx.Keeper.DeleteStorage(ctx.Context, msg.Key)
 		return "true", nil
 
 	case "get":
-		storage := ctx.Keeper.GetStorage(ctx.Context, msg.Key)
-		if storage.Value == "" {
+		gas.reads(1)
+		value, ok := ctx.Keeper.GetStorageBytes(ctx.Context, msg.Key)
+		if !ok {
 			return "null", nil
 		}
-		//fmt.Printf("Keeper.GetStorage gave us %s\n", storage.Value)
-		s, err := json.Marshal(storage.Value)
+		//fmt.Printf("Keeper.GetStorage gave us %s\n", value)
+		s, err := json.Marshal(string(value))
 		if err != nil {
 			return "", err
 		}
 		return string(s), nil
 
+	case "setMany":
+		gas.writes(len(msg.Entries))
+		cacheCtx, write := cacheContext(ctx.Context)
+		for _, entry := range msg.Entries {
+			if err := setValue(ctx, cacheCtx, entry[0], entry[1]); err != nil {
+				return "", err
+			}
+		}
+		write()
+		return "true", nil
+
+	case "getMany":
+		gas.reads(len(msg.Keys))
+		vals := make([]*string, len(msg.Keys))
+		for i, key := range msg.Keys {
+			if value, ok := ctx.Keeper.GetStorageBytes(ctx.Context, key); ok {
+				s := string(value)
+				vals[i] = &s
+			}
+		}
+		bytes, err := json.Marshal(vals)
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+
+	case "deleteMany":
+		gas.writes(len(msg.Keys))
+		cacheCtx, write := cacheContext(ctx.Context)
+		for _, key := range msg.Keys {
+			ctx.Keeper.DeleteStorage(cacheCtx, key)
+		}
+		write()
+		return "true", nil
+
+	case "setIfAbsent", "setIfEqual":
+		var expected []byte
+		expectedPresent := false
+		if msg.Method == "setIfEqual" && msg.Expected != nil {
+			expected = []byte(*msg.Expected)
+			expectedPresent = presentValue(ctx, *msg.Expected)
+		}
+		gas.reads(1)
+		cacheCtx, write := cacheContext(ctx.Context)
+		ok, value, present := ctx.Keeper.CompareAndSetStorage(
+			cacheCtx, msg.Key, expected, expectedPresent, []byte(msg.Value), presentValue(ctx, msg.Value),
+		)
+		if ok {
+			if present {
+				if err := ctx.Keeper.CheckStorageQuota(ctx.Context, msg.Key, value); err != nil {
+					return "", err
+				}
+			}
+			gas.writes(1)
+			write()
+		}
+		return conditionalReply(ok, value, present)
+
+	case "increment":
+		// The value is the decimal delta, by default 1.
+		delta := big.NewInt(1)
+		if msg.Value != "" {
+			if _, ok := delta.SetString(msg.Value, 10); !ok {
+				return "", fmt.Errorf("increment delta %q is not an integer", msg.Value)
+			}
+		}
+		gas.reads(1)
+		cacheCtx, write := cacheContext(ctx.Context)
+		value, present, err := ctx.Keeper.IncrementStorage(cacheCtx, msg.Key, delta)
+		if err == nil {
+			if err := ctx.Keeper.CheckStorageQuota(ctx.Context, msg.Key, value); err != nil {
+				return "", err
+			}
+			gas.writes(1)
+			write()
+		}
+		return conditionalReply(err == nil, value, present)
+
+	case "deleteSubtree":
+		cacheCtx, write := cacheContext(ctx.Context)
+		count := ctx.Keeper.DeleteStorageSubtree(cacheCtx, msg.Key)
+		gas.writes(count)
+		write()
+		return fmt.Sprint(count), nil
+
+	case "moveSubtree":
+		// The value is the destination path.
+		cacheCtx, write := cacheContext(ctx.Context)
+		count, err := ctx.Keeper.MoveStorageSubtree(cacheCtx, msg.Key, msg.Value)
+		if err != nil {
+			return "", err
+		}
+		// Each entry is deleted at the source and written at the destination.
+		gas.writes(2 * count)
+		write()
+		return fmt.Sprint(count), nil
+
 	case "has":
-		storage := ctx.Keeper.GetStorage(ctx.Context, msg.Key)
-		if storage.Value == "" {
+		gas.reads(1)
+		if _, ok := ctx.Keeper.GetStorageBytes(ctx.Context, msg.Key); !ok {
 			return "false", nil
 		}
 		return "true", nil
 
 	case "keys":
-		keys := ctx.Keeper.GetKeys(ctx.Context, msg.Key)
-		if keys.Keys == nil {
-			return "[]", nil
+		entries, err := listChildren(ctx, msg, false)
+		if err != nil {
+			return "", err
+		}
+		gas.reads(1 + len(entries))
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			keys[i] = entry.Key
 		}
-		bytes, err := json.Marshal(keys.Keys)
+		bytes, err := json.Marshal(keys)
 		if err != nil {
 			return "", err
 		}
 		return string(bytes), nil
 
 	case "entries":
-		keys := ctx.Keeper.GetKeys(ctx.Context, msg.Key)
-		ents := make([][]string, len(keys.Keys))
-		for i, key := range keys.Keys {
-			ents[i] = make([]string, 2)
-			ents[i][0] = key
-			storage := ctx.Keeper.GetStorage(ctx.Context, fmt.Sprintf("%s.%s", msg.Key, key))
-			ents[i][1] = storage.Value
+		entries, err := listChildren(ctx, msg, true)
+		if err != nil {
+			return "", err
+		}
+		gas.reads(1 + 2*len(entries))
+		ents := make([][]string, len(entries))
+		for i, entry := range entries {
+			ents[i] = []string{entry.Key, entry.Value}
 		}
 		bytes, err := json.Marshal(ents)
 		if err != nil {
@@ -102,11 +414,14 @@ func (sh storageHandler) Receive(ctx *ControllerContext, str string) (ret string
 		return string(bytes), nil
 
 	case "values":
-		keys := ctx.Keeper.GetKeys(ctx.Context, msg.Key)
-		vals := make([]string, len(keys.Keys))
-		for i, key := range keys.Keys {
-			storage := ctx.Keeper.GetStorage(ctx.Context, fmt.Sprintf("%s.%s", msg.Key, key))
-			vals[i] = storage.Value
+		entries, err := listChildren(ctx, msg, true)
+		if err != nil {
+			return "", err
+		}
+		gas.reads(1 + 2*len(entries))
+		vals := make([]string, len(entries))
+		for i, entry := range entries {
+			vals[i] = entry.Value
 		}
 		bytes, err := json.Marshal(vals)
 		if err != nil {
@@ -116,10 +431,8 @@ func (sh storageHandler) Receive(ctx *ControllerContext, str string) (ret string
 
 	case "size":
 		keys := ctx.Keeper.GetKeys(ctx.Context, msg.Key)
-		if keys.Keys == nil {
-			return "0", nil
-		}
-		return string(len(keys.Keys)), nil
+		gas.reads(1 + len(keys.Keys))
+		return strconv.Itoa(len(keys.Keys)), nil
 	}
 
 	return "", errors.New("Unrecognized msg.Method " + msg.Method)
@@ -0,0 +1,337 @@
+package swingset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	capabilitykeeper "github.com/cosmos/cosmos-sdk/x/capability/keeper"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// newTestControllerContext returns the context of a storage downcall against
+// a Keeper backed by memory.
+func newTestControllerContext(t testing.TB, emptyValues bool) *ControllerContext {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	paramsKey := sdk.NewKVStoreKey(paramtypes.StoreKey)
+	paramsTKey := sdk.NewTransientStoreKey(paramtypes.TStoreKey)
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, nil)
+	cms.MountStoreWithDB(paramsTKey, sdk.StoreTypeTransient, nil)
+	if err := cms.LoadLatestVersion(); err != nil {
+		t.Fatal(err)
+	}
+
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	paramSpace := paramtypes.NewSubspace(cdc, codec.NewLegacyAmino(), paramsKey, paramsTKey, types.ModuleName)
+	k := NewKeeper(
+		cdc, key, paramSpace, nil, nil,
+		authkeeper.AccountKeeper{}, nil, capabilitykeeper.ScopedKeeper{},
+	)
+	return &ControllerContext{
+		Keeper:             &k,
+		Context:            sdk.NewContext(cms, tmproto.Header{}, false, log.NewNopLogger()),
+		GasMeter:           sdk.NewInfiniteGasMeter(),
+		EmptyStorageValues: emptyValues,
+	}
+}
+
+// storageCall sends a storage downcall and returns its reply.
+func storageCall(t *testing.T, ctx *ControllerContext, msg map[string]interface{}) string {
+	t.Helper()
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := NewStorageHandler().Receive(ctx, string(bz))
+	if err != nil {
+		t.Fatalf("%s: %v", bz, err)
+	}
+	return reply
+}
+
+func TestConditionalWritesOfEmptyValues(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	storageCall(t, ctx, map[string]interface{}{"method": "set", "key": "empty", "value": ""})
+
+	for _, tc := range []struct {
+		msg   map[string]interface{}
+		reply string
+	}{
+		{map[string]interface{}{"method": "has", "key": "empty"}, `true`},
+		{map[string]interface{}{"method": "setIfAbsent", "key": "empty", "value": "x"}, `{"ok":false,"value":""}`},
+		{map[string]interface{}{"method": "setIfEqual", "key": "empty", "value": "x", "expected": nil}, `{"ok":false,"value":""}`},
+		{map[string]interface{}{"method": "setIfEqual", "key": "absent", "value": "x", "expected": ""}, `{"ok":false,"value":null}`},
+		{map[string]interface{}{"method": "setIfEqual", "key": "absent", "value": "", "expected": nil}, `{"ok":true,"value":""}`},
+		{map[string]interface{}{"method": "setIfEqual", "key": "empty", "value": "x", "expected": ""}, `{"ok":true,"value":"x"}`},
+		{map[string]interface{}{"method": "increment", "key": "absent"}, `{"ok":false,"value":""}`},
+		{map[string]interface{}{"method": "increment", "key": "counter"}, `{"ok":true,"value":"1"}`},
+	} {
+		if reply := storageCall(t, ctx, tc.msg); reply != tc.reply {
+			t.Errorf("%v replied %s, want %s", tc.msg, reply, tc.reply)
+		}
+	}
+}
+
+func TestConditionalWritesWithoutEmptyValues(t *testing.T) {
+	ctx := newTestControllerContext(t, false)
+
+	// An old controller means absent by "".
+	for _, tc := range []struct {
+		msg   map[string]interface{}
+		reply string
+	}{
+		{map[string]interface{}{"method": "setIfEqual", "key": "k", "value": "a", "expected": ""}, `{"ok":true,"value":"a"}`},
+		{map[string]interface{}{"method": "setIfAbsent", "key": "k", "value": "b"}, `{"ok":false,"value":"a"}`},
+		{map[string]interface{}{"method": "setIfEqual", "key": "k", "value": "", "expected": "a"}, `{"ok":true,"value":null}`},
+		{map[string]interface{}{"method": "has", "key": "k"}, `false`},
+	} {
+		if reply := storageCall(t, ctx, tc.msg); reply != tc.reply {
+			t.Errorf("%v replied %s, want %s", tc.msg, reply, tc.reply)
+		}
+	}
+}
+
+func TestStoragePathsValidated(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	for _, msg := range []map[string]interface{}{
+		{"method": "set", "key": "", "value": "x"},
+		{"method": "set", "key": "a..b", "value": "x"},
+		{"method": "delete", "key": "a."},
+		{"method": "get", "key": `a\b`},
+		{"method": "setMany", "entries": [][]string{{"a", "1"}, {".b", "2"}}},
+		{"method": "getMany", "keys": []string{"a", ""}},
+		{"method": "deleteMany", "keys": []string{"a", "b."}},
+		{"method": "deleteSubtree", "key": ""},
+		{"method": "moveSubtree", "key": "a", "value": "b..c"},
+		{"method": "keys", "key": "a.."},
+	} {
+		bz, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply, err := NewStorageHandler().Receive(ctx, string(bz)); err == nil {
+			t.Errorf("%s replied %s, want an error", bz, reply)
+		}
+	}
+
+	if reply := storageCall(t, ctx, map[string]interface{}{"method": "keys", "key": ""}); reply != `[]` {
+		t.Errorf("root keys are %s", reply)
+	}
+}
+
+func TestStorageMethodLabels(t *testing.T) {
+	sink := metrics.NewInmemSink(time.Hour, time.Hour)
+	if _, err := metrics.NewGlobal(metrics.DefaultConfig(""), sink); err != nil {
+		t.Fatal(err)
+	}
+	defer metrics.NewGlobal(metrics.DefaultConfig(""), &metrics.BlackholeSink{})
+
+	ctx := newTestControllerContext(t, true)
+	for _, method := range []string{"get", "made-up", "also-made-up"} {
+		bz, err := json.Marshal(map[string]string{"method": method, "key": "a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = NewStorageHandler().Receive(ctx, string(bz))
+		if ok := err == nil; ok != (method == "get") {
+			t.Errorf("%s gave %v", method, err)
+		}
+	}
+
+	methods := map[string]int{}
+	for _, interval := range sink.Data() {
+		for _, counter := range interval.Counters {
+			for _, label := range counter.Labels {
+				if label.Name == "method" {
+					methods[label.Value] += counter.Count
+				}
+			}
+		}
+	}
+	if len(methods) != 2 || methods["get"] != 1 || methods["unknown"] != 2 {
+		t.Errorf("counted storage methods %v", methods)
+	}
+}
+
+func TestStorageGas(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	params := types.DefaultParams()
+	params.StorageGas = types.StorageGas{Read: 10, Write: 100, PerByte: 1}
+	ctx.Keeper.SetParams(ctx.Context, params)
+
+	// Each downcall is charged for its keys, and for the bytes of its keys,
+	// values and reply.  Only entries with values are listed, so c is not.
+	for _, tc := range []struct {
+		msg   map[string]interface{}
+		reply string
+		gas   sdk.Gas
+	}{
+		{map[string]interface{}{"method": "set", "key": "a", "value": "xyz"}, `true`, 100 + 4 + 4},
+		{map[string]interface{}{"method": "get", "key": "a"}, `"xyz"`, 10 + 1 + 5},
+		{map[string]interface{}{"method": "get", "key": "b"}, `null`, 10 + 1 + 4},
+		{map[string]interface{}{"method": "has", "key": "a"}, `true`, 10 + 1 + 4},
+		{map[string]interface{}{"method": "setMany", "entries": [][]string{{"b", "1"}, {"c.d", "22"}}}, `true`, 200 + 7 + 4},
+		{map[string]interface{}{"method": "getMany", "keys": []string{"a", "b", "e"}}, `["xyz","1",null]`, 30 + 3 + 16},
+		{map[string]interface{}{"method": "keys", "key": ""}, `["a","b"]`, 30 + 9},
+		{map[string]interface{}{"method": "size", "key": ""}, `2`, 30 + 1},
+		{map[string]interface{}{"method": "size", "key": "a"}, `0`, 10 + 1 + 1},
+		{map[string]interface{}{"method": "entries", "key": "c"}, `[["d","22"]]`, 30 + 1 + 12},
+		{map[string]interface{}{"method": "values", "key": "c"}, `["22"]`, 30 + 1 + 6},
+		{map[string]interface{}{"method": "increment", "key": "n", "value": "5"}, `{"ok":true,"value":"5"}`, 10 + 100 + 2 + 23},
+		{map[string]interface{}{"method": "setIfAbsent", "key": "a", "value": "w"}, `{"ok":false,"value":"xyz"}`, 10 + 2 + 26},
+		{map[string]interface{}{"method": "moveSubtree", "key": "c", "value": "m"}, `1`, 200 + 2 + 1},
+		{map[string]interface{}{"method": "deleteMany", "keys": []string{"a", "b"}}, `true`, 200 + 2 + 4},
+		{map[string]interface{}{"method": "deleteSubtree", "key": "m"}, `1`, 100 + 1 + 1},
+		{map[string]interface{}{"method": "delete", "key": "n"}, `true`, 100 + 1 + 4},
+	} {
+		ctx.GasMeter = sdk.NewInfiniteGasMeter()
+		if reply := storageCall(t, ctx, tc.msg); reply != tc.reply {
+			t.Errorf("%v replied %s, want %s", tc.msg, reply, tc.reply)
+		}
+		if gas := ctx.GasMeter.GasConsumed(); gas != tc.gas {
+			t.Errorf("%v used %d gas, want %d", tc.msg, gas, tc.gas)
+		}
+	}
+}
+
+func TestStorageOutOfGas(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	params := types.DefaultParams()
+	params.StorageGas = types.StorageGas{Read: 10, Write: 100, PerByte: 1}
+	ctx.Keeper.SetParams(ctx.Context, params)
+
+	ctx.GasMeter = sdk.NewGasMeter(50)
+	_, err := NewStorageHandler().Receive(ctx, `{"method":"set","key":"a","value":"x"}`)
+	if !errors.Is(err, sdkerrors.ErrOutOfGas) {
+		t.Fatalf("set with too little gas gave %v", err)
+	}
+	if _, ok := ctx.Keeper.GetStorageBytes(ctx.Context, "a"); ok {
+		t.Error("set with too little gas was written")
+	}
+
+	// A schedule too large to multiply out saturates the meter.
+	params.StorageGas = types.StorageGas{PerByte: math.MaxUint64 / 2}
+	ctx.Keeper.SetParams(ctx.Context, params)
+	ctx.GasMeter = sdk.NewInfiniteGasMeter()
+	storageCall(t, ctx, map[string]interface{}{"method": "get", "key": "a"})
+	if gas := ctx.GasMeter.GasConsumed(); gas != math.MaxUint64 {
+		t.Errorf("used %d gas, want the maximum", gas)
+	}
+}
+
+func TestStorageListingPages(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	for _, key := range []string{"k0", "k1", "k2", `x\.y`} {
+		storageCall(t, ctx, map[string]interface{}{"method": "set", "key": "a." + key, "value": "v" + key})
+	}
+	storageCall(t, ctx, map[string]interface{}{"method": "set", "key": "a.k1.z", "value": "below"})
+
+	for _, tc := range []struct {
+		msg   map[string]interface{}
+		reply string
+	}{
+		{map[string]interface{}{"method": "keys", "key": "a"}, `["k0","k1","k2","x.y"]`},
+		{map[string]interface{}{"method": "keys", "key": "a", "limit": 2}, `["k0","k1"]`},
+		{map[string]interface{}{"method": "keys", "key": "a", "after": "k1", "limit": 2}, `["k2","x.y"]`},
+		{map[string]interface{}{"method": "keys", "key": "a", "after": "x.y", "limit": 2}, `[]`},
+		{map[string]interface{}{"method": "entries", "key": "a", "after": "k0", "limit": 1}, `[["k1","vk1"]]`},
+		{map[string]interface{}{"method": "entries", "key": "a", "after": "k2"}, `[["x.y","vx\\.y"]]`},
+		{map[string]interface{}{"method": "values", "key": "a", "after": "k0", "limit": 2}, `["vk1","vk2"]`},
+		{map[string]interface{}{"method": "values", "key": "none"}, `[]`},
+	} {
+		if reply := storageCall(t, ctx, tc.msg); reply != tc.reply {
+			t.Errorf("%v replied %s, want %s", tc.msg, reply, tc.reply)
+		}
+	}
+}
+
+func TestStorageListingBounded(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	entries := make([][]string, maxStorageListing+1)
+	for i := range entries {
+		entries[i] = []string{fmt.Sprintf("a.k%04d", i), "v"}
+	}
+	storageCall(t, ctx, map[string]interface{}{"method": "setMany", "entries": entries})
+
+	for _, method := range []string{"keys", "entries", "values"} {
+		bz, err := json.Marshal(map[string]interface{}{"method": method, "key": "a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewStorageHandler().Receive(ctx, string(bz)); err == nil {
+			t.Errorf("%s listed more than %d children without a limit", method, maxStorageListing)
+		}
+
+		// A larger limit still gets at most maxStorageListing.
+		reply := storageCall(t, ctx, map[string]interface{}{"method": method, "key": "a", "limit": 2 * maxStorageListing})
+		var page []interface{}
+		if err := json.Unmarshal([]byte(reply), &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != maxStorageListing {
+			t.Errorf("%s listed %d children, want %d", method, len(page), maxStorageListing)
+		}
+	}
+	if reply := storageCall(t, ctx, map[string]interface{}{"method": "size", "key": "a"}); reply != fmt.Sprint(maxStorageListing+1) {
+		t.Errorf("size is %s", reply)
+	}
+}
+
+func TestManyRollsBack(t *testing.T) {
+	ctx := newTestControllerContext(t, true)
+	params := types.DefaultParams()
+	params.MaxValueSize = 5
+	ctx.Keeper.SetParams(ctx.Context, params)
+	storageCall(t, ctx, map[string]interface{}{"method": "setMany", "entries": [][]string{{"a", "old"}, {"b", "old"}}})
+	usage := ctx.Keeper.GetStorageUsage(ctx.Context, "")
+
+	for _, msg := range []map[string]interface{}{
+		// The last entry is over the value size limit.
+		{"method": "setMany", "entries": [][]string{{"a", "new"}, {"c", "new"}, {"d", "too large"}}},
+		// The last key is invalid.
+		{"method": "setMany", "entries": [][]string{{"a", "new"}, {"c", "new"}, {"d.", "new"}}},
+		{"method": "deleteMany", "keys": []string{"a", "b", "c..d"}},
+	} {
+		bz, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx.Context = ctx.Context.WithEventManager(sdk.NewEventManager())
+		if _, err := NewStorageHandler().Receive(ctx, string(bz)); err == nil {
+			t.Fatalf("%s succeeded", bz)
+		}
+		for key, want := range map[string]string{"a": "old", "b": "old"} {
+			if value, ok := ctx.Keeper.GetStorageBytes(ctx.Context, key); !ok || string(value) != want {
+				t.Errorf("after %s, %s is %q (present %v)", bz, key, value, ok)
+			}
+		}
+		if _, ok := ctx.Keeper.GetStorageBytes(ctx.Context, "c"); ok {
+			t.Errorf("after %s, c was set", bz)
+		}
+		if events := ctx.Context.EventManager().Events(); len(events) != 0 {
+			t.Errorf("%s emitted %d events", bz, len(events))
+		}
+		if got := ctx.Keeper.GetStorageUsage(ctx.Context, ""); got != usage {
+			t.Errorf("after %s, usage is %+v, want %+v", bz, got, usage)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package swingset
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// Metric keys published by the swingset module, under the "swingset" prefix.
+const (
+	MetricKeyUpcall           = "upcall"
+	MetricKeyUpcallError      = "upcall_error"
+	MetricKeyDowncall         = "downcall"
+	MetricKeyStorageMethod    = "storage_method"
+	MetricKeyBytesSent        = "bytes_sent"
+	MetricKeyBytesReceived    = "bytes_received"
+	MetricKeyDowncallsInBlock = "downcalls_per_block"
+)
+
+// countUpcall records an upcall of actionType that started at start, and its
+// reply.
+func countUpcall(actionType string, start time.Time, str, reply string, err error) {
+	telemetry.ModuleMeasureSince(ModuleName, start, MetricKeyUpcall, actionType)
+	telemetry.IncrCounter(float32(len(str)), ModuleName, MetricKeyBytesSent)
+	telemetry.IncrCounter(float32(len(reply)), ModuleName, MetricKeyBytesReceived)
+	if err != nil {
+		telemetry.IncrCounterWithLabels(
+			[]string{ModuleName, MetricKeyUpcallError}, 1,
+			[]metrics.Label{telemetry.NewLabel("type", actionType)},
+		)
+	}
+}
+
+// countDowncall records a downcall and its reply.
+func (c *Controller) countDowncall(port string, msg, reply string) {
+	c.mu.Lock()
+	c.downcallsInBlock++
+	c.mu.Unlock()
+
+	telemetry.IncrCounterWithLabels(
+		[]string{ModuleName, MetricKeyDowncall}, 1,
+		[]metrics.Label{telemetry.NewLabel("port", port)},
+	)
+	telemetry.IncrCounter(float32(len(msg)), ModuleName, MetricKeyBytesReceived)
+	telemetry.IncrCounter(float32(len(reply)), ModuleName, MetricKeyBytesSent)
+}
+
+// reportBlockDowncalls publishes and resets the number of downcalls made
+// since the last report.
+func (c *Controller) reportBlockDowncalls() {
+	c.mu.Lock()
+	n := c.downcallsInBlock
+	c.downcallsInBlock = 0
+	c.mu.Unlock()
+	telemetry.SetGauge(float32(n), ModuleName, MetricKeyDowncallsInBlock)
+}
+
+// countStorageMethod records a storage-port method by name.
+func countStorageMethod(method string) {
+	telemetry.IncrCounterWithLabels(
+		[]string{ModuleName, MetricKeyStorageMethod}, 1,
+		[]metrics.Label{telemetry.NewLabel("method", method)},
+	)
+}
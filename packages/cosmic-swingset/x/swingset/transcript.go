@@ -0,0 +1,299 @@
+package swingset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Directions of controller traffic recorded in a transcript.
+const (
+	TranscriptUpcall        = "upcall"
+	TranscriptUpcallReply   = "upcall-reply"
+	TranscriptDowncall      = "downcall"
+	TranscriptDowncallReply = "downcall-reply"
+)
+
+// TranscriptEntry is one line of a controller transcript.
+type TranscriptEntry struct {
+	Seq    uint64 `json:"seq"`
+	Height int64  `json:"height"`
+	Dir    string `json:"dir"`
+	Port   int    `json:"port,omitempty"`
+	Body   string `json:"body"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Recorder appends all controller traffic to a transcript file.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	seq  uint64
+}
+
+// NewRecorder opens (or creates) an append-only transcript file.  Sequence
+// numbers continue from the last entry already in the file.
+func NewRecorder(path string) (*Recorder, error) {
+	seq, err := lastTranscriptSeq(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: file, seq: seq}, nil
+}
+
+// lastTranscriptSeq returns the sequence number of the last entry in the
+// transcript at path, or 0 if there is none.
+func lastTranscriptSeq(path string) (uint64, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var seq uint64
+	dec := json.NewDecoder(file)
+	for {
+		var entry TranscriptEntry
+		if err := dec.Decode(&entry); err == io.EOF {
+			return seq, nil
+		} else if err != nil {
+			return 0, fmt.Errorf("cannot resume transcript %s after seq %d: %w", path, seq, err)
+		}
+		seq = entry.Seq
+	}
+}
+
+// Record appends an entry, assigning it the next sequence number.
+func (r *Recorder) Record(entry TranscriptEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	entry.Seq = r.seq
+	bz, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	_, err = r.file.Write(append(bz, '\n'))
+	return err
+}
+
+// WrapSender returns a sender that records each upcall and its reply.
+func (r *Recorder) WrapSender(
+	send func(context.Context, bool, string) (string, error),
+) func(context.Context, bool, string) (string, error) {
+	return func(ctx context.Context, needReply bool, str string) (string, error) {
+		height := actionHeight(str)
+		r.record(TranscriptEntry{Height: height, Dir: TranscriptUpcall, Body: str}, nil)
+		ret, err := send(ctx, needReply, str)
+		r.record(TranscriptEntry{Height: height, Dir: TranscriptUpcallReply, Body: ret}, err)
+		return ret, err
+	}
+}
+
+// Close closes the transcript file.  Later entries fail to record.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func (r *Recorder) record(entry TranscriptEntry, err error) {
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if rerr := r.Record(entry); rerr != nil {
+		fmt.Fprintln(os.Stderr, "Cannot record controller transcript", rerr)
+	}
+}
+
+// actionHeight extracts the blockHeight that every action carries.
+func actionHeight(str string) int64 {
+	var action struct {
+		BlockHeight int64 `json:"blockHeight"`
+	}
+	if err := json.Unmarshal([]byte(str), &action); err != nil {
+		return 0
+	}
+	return action.BlockHeight
+}
+
+// Divergence describes where a replayed run differed from its transcript.
+type Divergence struct {
+	Seq      uint64
+	Height   int64
+	Dir      string
+	Expected string
+	Actual   string
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf(
+		"transcript divergence at seq %d (height %d, %s): expected %q, got %q",
+		d.Seq, d.Height, d.Dir, d.Expected, d.Actual,
+	)
+}
+
+// Replayer stands in for a live controller by feeding a recorded transcript
+// back into the node.  The recorded downcalls are reissued against the
+// node's storage and IBC ports, and every reply that doesn't match the
+// recording is flagged as a Divergence.
+type Replayer struct {
+	mu          sync.Mutex
+	dec         *json.Decoder
+	file        io.Closer
+	next        *TranscriptEntry
+	receive     func(port int, msg string) (string, error)
+	divergences []Divergence
+
+	// Strict makes the first divergence fail the upcall.
+	Strict bool
+	// OnDivergence is called for each divergence; it defaults to logging.
+	OnDivergence func(Divergence)
+}
+
+// NewReplayer opens a transcript for replay.  Downcalls are issued through
+// receive.
+func NewReplayer(path string, receive func(port int, msg string) (string, error)) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		dec:     json.NewDecoder(file),
+		file:    file,
+		receive: receive,
+		OnDivergence: func(d Divergence) {
+			fmt.Fprintln(os.Stderr, d)
+		},
+	}, nil
+}
+
+// Divergences returns all the divergences flagged so far.
+func (rp *Replayer) Divergences() []Divergence {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	return append([]Divergence(nil), rp.divergences...)
+}
+
+// Close closes the transcript file.
+func (rp *Replayer) Close() error {
+	return rp.file.Close()
+}
+
+// Send implements the cmd.Sender contract by replaying the transcript.
+func (rp *Replayer) Send(ctx context.Context, needReply bool, str string) (string, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	upcall, err := rp.expect(TranscriptUpcall)
+	if err != nil {
+		return "", err
+	}
+	if upcall.Body != str {
+		if err := rp.diverge(upcall, upcall.Body, str); err != nil {
+			return "", err
+		}
+	}
+
+	// Reissue the downcalls the controller made while handling this upcall.
+	for {
+		entry, err := rp.peek()
+		if err != nil {
+			return "", err
+		}
+		if entry.Dir != TranscriptDowncall {
+			break
+		}
+		rp.next = nil
+
+		// The handler may make nested upcalls, which the transcript records
+		// before the downcall's reply, so don't hold the lock over it.
+		rp.mu.Unlock()
+		ret, rerr := rp.receive(entry.Port, entry.Body)
+		rp.mu.Lock()
+
+		reply, err := rp.expect(TranscriptDowncallReply)
+		if err != nil {
+			return "", err
+		}
+		expected, actual := reply.Body, ret
+		if reply.Error != "" || rerr != nil {
+			expected, actual = reply.Error, errorString(rerr)
+		}
+		if expected != actual {
+			if err := rp.diverge(reply, expected, actual); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	reply, err := rp.expect(TranscriptUpcallReply)
+	if err != nil {
+		return "", err
+	}
+	if reply.Error != "" {
+		return reply.Body, errors.New(reply.Error)
+	}
+	return reply.Body, nil
+}
+
+func (rp *Replayer) diverge(entry *TranscriptEntry, expected, actual string) error {
+	d := Divergence{
+		Seq:      entry.Seq,
+		Height:   entry.Height,
+		Dir:      entry.Dir,
+		Expected: expected,
+		Actual:   actual,
+	}
+	rp.divergences = append(rp.divergences, d)
+	if rp.OnDivergence != nil {
+		rp.OnDivergence(d)
+	}
+	if rp.Strict {
+		return errors.New(d.String())
+	}
+	return nil
+}
+
+func (rp *Replayer) peek() (*TranscriptEntry, error) {
+	if rp.next == nil {
+		entry := new(TranscriptEntry)
+		if err := rp.dec.Decode(entry); err != nil {
+			if err == io.EOF {
+				return nil, errors.New("controller transcript is exhausted")
+			}
+			return nil, err
+		}
+		rp.next = entry
+	}
+	return rp.next, nil
+}
+
+func (rp *Replayer) expect(dir string) (*TranscriptEntry, error) {
+	entry, err := rp.peek()
+	if err != nil {
+		return nil, err
+	}
+	if entry.Dir != dir {
+		return nil, fmt.Errorf("transcript seq %d is %s; expected %s", entry.Seq, entry.Dir, dir)
+	}
+	rp.next = nil
+	return entry, nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
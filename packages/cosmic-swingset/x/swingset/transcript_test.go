@@ -0,0 +1,134 @@
+package swingset
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReplayNestedUpcall(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transcript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "transcript.jsonl")
+
+	// A downcall whose handler made a nested upcall.
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []TranscriptEntry{
+		{Dir: TranscriptUpcall, Body: "outer"},
+		{Dir: TranscriptDowncall, Port: 1, Body: "down"},
+		{Dir: TranscriptUpcall, Body: "inner"},
+		{Dir: TranscriptUpcallReply, Body: "inner done"},
+		{Dir: TranscriptDowncallReply, Port: 1, Body: "down done"},
+		{Dir: TranscriptUpcallReply, Body: "outer done"},
+	} {
+		if err := recorder.Record(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recorder.Close()
+
+	var replayer *Replayer
+	replayer, err = NewReplayer(path, func(port int, msg string) (string, error) {
+		if _, err := replayer.Send(context.Background(), true, "inner"); err != nil {
+			return "", err
+		}
+		return msg + " done", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replayer.Close()
+	replayer.Strict = true
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := replayer.Send(context.Background(), true, "outer")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("replaying a nested upcall deadlocked")
+	}
+}
+
+func TestRecorderResumesSeq(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transcript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "transcript.jsonl")
+
+	// Two runs of the node append to the same transcript.
+	for run := 0; run < 2; run++ {
+		recorder, err := NewRecorder(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, dir := range []string{TranscriptUpcall, TranscriptUpcallReply} {
+			if err := recorder.Record(TranscriptEntry{Dir: dir}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := recorder.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := recorder.Record(TranscriptEntry{Dir: TranscriptUpcall}); err == nil {
+			t.Error("recorded after Close")
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	dec := json.NewDecoder(file)
+	var seqs []uint64
+	for dec.More() {
+		var entry TranscriptEntry
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, entry.Seq)
+	}
+	if len(seqs) != 4 {
+		t.Fatalf("transcript has seqs %v", seqs)
+	}
+	for i, seq := range seqs {
+		if seq != uint64(i+1) {
+			t.Errorf("transcript has seqs %v", seqs)
+			break
+		}
+	}
+}
+
+func TestRecorderMalformedTranscript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transcript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "transcript.jsonl")
+
+	if err := ioutil.WriteFile(path, []byte(`{"seq":1,"dir":"upcall"}`+"\n"+`{"seq":2,"di`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRecorder(path); err == nil {
+		t.Error("resumed a truncated transcript")
+	}
+}
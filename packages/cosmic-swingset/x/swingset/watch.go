@@ -0,0 +1,144 @@
+package swingset
+
+import (
+	"strings"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+// storageWatchBuffer is how many changes a subscriber may fall behind before
+// it is dropped.
+const storageWatchBuffer = 1024
+
+type storageSubscription struct {
+	path    string
+	changes chan types.StorageChange
+}
+
+// matches returns whether path is at or below the subscribed path.
+func (sub *storageSubscription) matches(path string) bool {
+	return sub.path == "" || path == sub.path || strings.HasPrefix(path, sub.path+".")
+}
+
+// StorageWatcher publishes committed storage changes to subscribers.  The
+// changes are found in the storage events of each block, so that writes that
+// were rolled back are not published.
+type StorageWatcher struct {
+	mu      sync.Mutex
+	pending map[string]bool
+	paths   []string
+	subs    map[*storageSubscription]bool
+}
+
+var _ types.WatchServer = (*StorageWatcher)(nil)
+
+// NewStorageWatcher returns a StorageWatcher without subscribers.
+func NewStorageWatcher() *StorageWatcher {
+	return &StorageWatcher{
+		pending: make(map[string]bool),
+		subs:    make(map[*storageSubscription]bool),
+	}
+}
+
+// CollectEvents notes the storage paths written by a block's events.
+func (w *StorageWatcher) CollectEvents(events []abci.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, event := range events {
+		if event.Type != types.EventTypeStorage {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != types.AttributeKeyPath {
+				continue
+			}
+			path := string(attr.Value)
+			if !w.pending[path] {
+				w.pending[path] = true
+				w.paths = append(w.paths, path)
+			}
+		}
+	}
+}
+
+// Commit publishes the committed value of each path written since the last
+// commit, as read by getValue, which also reports whether the path has a
+// value at all.
+func (w *StorageWatcher) Commit(blockHeight int64, getValue func(path string) ([]byte, bool)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	paths := w.paths
+	w.pending = make(map[string]bool)
+	w.paths = nil
+	if len(w.subs) == 0 {
+		return
+	}
+
+	for _, path := range paths {
+		value, present := getValue(path)
+		change := types.StorageChange{
+			Path:        path,
+			Value:       string(value),
+			BlockHeight: blockHeight,
+			Deleted:     !present,
+		}
+		for sub := range w.subs {
+			if !sub.matches(path) {
+				continue
+			}
+			select {
+			case sub.changes <- change:
+			default:
+				// Don't hold up the chain for a slow subscriber.
+				delete(w.subs, sub)
+				close(sub.changes)
+			}
+		}
+	}
+}
+
+// Subscribe returns the changes that will be committed at or below path, and
+// a function to cancel the subscription.  The channel is closed if the
+// subscriber falls too far behind.
+func (w *StorageWatcher) Subscribe(path string) (<-chan types.StorageChange, func()) {
+	sub := &storageSubscription{
+		path:    path,
+		changes: make(chan types.StorageChange, storageWatchBuffer),
+	}
+	w.mu.Lock()
+	w.subs[sub] = true
+	w.mu.Unlock()
+
+	return sub.changes, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.subs[sub] {
+			delete(w.subs, sub)
+			close(sub.changes)
+		}
+	}
+}
+
+// Storage implements types.WatchServer.
+func (w *StorageWatcher) Storage(req *types.WatchStorageRequest, stream types.Watch_StorageServer) error {
+	changes, cancel := w.Subscribe(types.PathString(req.Path))
+	defer cancel()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case change, ok := <-changes:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "storage watcher fell behind")
+			}
+			if err := stream.Send(&change); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package swingset
+
+import (
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Agoric/cosmic-swingset/x/swingset/internal/types"
+)
+
+func storageEvent(path string) abci.Event {
+	return abci.Event{
+		Type: types.EventTypeStorage,
+		Attributes: []abci.EventAttribute{
+			{Key: []byte(types.AttributeKeyPath), Value: []byte(path)},
+		},
+	}
+}
+
+func TestStorageWatcherDeleted(t *testing.T) {
+	w := NewStorageWatcher()
+	changes, cancel := w.Subscribe("a")
+	defer cancel()
+
+	committed := map[string]string{"a.empty": "", "a.set": "value"}
+	w.CollectEvents([]abci.Event{storageEvent("a.empty"), storageEvent("a.set"), storageEvent("a.gone"), storageEvent("b")})
+	w.Commit(5, func(path string) ([]byte, bool) {
+		value, ok := committed[path]
+		return []byte(value), ok
+	})
+
+	for _, want := range []types.StorageChange{
+		{Path: "a.empty", Value: "", BlockHeight: 5},
+		{Path: "a.set", Value: "value", BlockHeight: 5},
+		{Path: "a.gone", Value: "", BlockHeight: 5, Deleted: true},
+	} {
+		select {
+		case got := <-changes:
+			if got != want {
+				t.Errorf("change is %+v; want %+v", got, want)
+			}
+		default:
+			t.Fatalf("missing change %+v", want)
+		}
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("unexpected change %+v", got)
+	default:
+	}
+}